@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// commandArgs holds a command's arguments split into positional arguments and flags.
+type commandArgs struct {
+	positional []string
+	flags      map[string]string
+}
+
+// parseArgs splits args into positional arguments and flags. Flags start with
+// "-" and may carry a value as "--name=value". Flags listed in valueFlags also
+// take the following argument as their value ("--name value").
+func parseArgs(args []string, valueFlags ...string) commandArgs {
+	parsed := commandArgs{flags: make(map[string]string)}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || len(arg) == 1 {
+			parsed.positional = append(parsed.positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue && i+1 < len(args) && slices.Contains(valueFlags, name) {
+			i++
+			value = args[i]
+		}
+		parsed.flags[name] = value
+	}
+	return parsed
+}
+
+// has reports whether the named flag was given.
+func (a commandArgs) has(name string) bool {
+	_, ok := a.flags[name]
+	return ok
+}
+
+// value returns the value of the named flag and whether it was given.
+func (a commandArgs) value(name string) (string, bool) {
+	v, ok := a.flags[name]
+	return v, ok
+}
+
+// intValue returns the named flag parsed as an integer, or def if it was not given.
+func (a commandArgs) intValue(name string, def int) (int, error) {
+	v, ok := a.flags[name]
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s expects a number, got %q", name, v)
+	}
+	return n, nil
+}
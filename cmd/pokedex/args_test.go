@@ -0,0 +1,41 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	parsed := parseArgs(
+		[]string{"char", "--sort=length", "--limit", "5", "-y", "extra"},
+		"--limit",
+	)
+
+	if !slices.Equal(parsed.positional, []string{"char", "extra"}) {
+		t.Errorf("unexpected positional arguments: %v", parsed.positional)
+	}
+
+	if v, ok := parsed.value("--sort"); !ok || v != "length" {
+		t.Errorf("expected --sort=length, got %q (present: %v)", v, ok)
+	}
+
+	if n, err := parsed.intValue("--limit", 0); err != nil || n != 5 {
+		t.Errorf("expected --limit 5, got %d (err: %v)", n, err)
+	}
+
+	if !parsed.has("-y") {
+		t.Error("expected -y to be present")
+	}
+
+	if parsed.has("--all") {
+		t.Error("expected --all to be absent")
+	}
+
+	if n, err := parsed.intValue("--missing", 20); err != nil || n != 20 {
+		t.Errorf("expected default 20, got %d (err: %v)", n, err)
+	}
+
+	if _, err := parseArgs([]string{"--limit=abc"}).intValue("--limit", 0); err == nil {
+		t.Error("expected an error for a non-numeric value")
+	}
+}
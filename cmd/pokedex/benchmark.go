@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// benchmarkResult holds the timings of repeated fetches of the same resource.
+type benchmarkResult struct {
+	// cold is the duration of the first fetch.
+	cold time.Duration
+
+	// cached holds the durations of every later fetch.
+	cached []time.Duration
+}
+
+// average returns the mean duration of the cached fetches.
+func (r benchmarkResult) average() time.Duration {
+	if len(r.cached) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range r.cached {
+		total += d
+	}
+	return total / time.Duration(len(r.cached))
+}
+
+// runBenchmark calls fetch iterations times, timing each call. It stops at the
+// first error.
+func runBenchmark(iterations int, fetch func() error) (benchmarkResult, error) {
+	var result benchmarkResult
+	for i := range iterations {
+		start := time.Now()
+		if err := fetch(); err != nil {
+			return result, err
+		}
+		d := time.Since(start)
+		if i == 0 {
+			result.cold = d
+		} else {
+			result.cached = append(result.cached, d)
+		}
+	}
+	return result, nil
+}
+
+// benchmarkArea fetches a location area iterations times with cache notices
+// silenced, abandoning a request once ctx is done. warm reports whether the
+// first fetch was already a cache hit.
+func benchmarkArea(ctx context.Context, client *pokeapi.Client, area string, iterations int) (result benchmarkResult, warm bool, err error) {
+	client = client.Silent()
+	hitsBefore := client.Stats().Hits
+	first := true
+	result, err = runBenchmark(iterations, func() error {
+		_, err := client.GetLocationAreaContext(ctx, area)
+		if first {
+			warm = client.Stats().Hits > hitsBefore
+			first = false
+		}
+		return err
+	})
+	return result, warm, err
+}
+
+// writeBenchmark prints a report comparing the cold fetch with the cached ones.
+// warm marks a first fetch that was already served from the cache.
+func writeBenchmark(w io.Writer, area string, result benchmarkResult, warm bool) {
+	fmt.Fprintf(w, "Benchmark for %s (%d iterations):\n", area, len(result.cached)+1)
+	if warm {
+		fmt.Fprintf(w, "  First fetch:    %s (already cached)\n", result.cold)
+	} else {
+		fmt.Fprintf(w, "  First fetch:    %s\n", result.cold)
+	}
+
+	avg := result.average()
+	fmt.Fprintf(w, "  Cached average: %s\n", avg)
+	if avg > 0 {
+		fmt.Fprintf(w, "  Speedup:        %.1fx\n", float64(result.cold)/float64(avg))
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	calls := 0
+	result, err := runBenchmark(4, func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 fetches, got %d", calls)
+	}
+	if len(result.cached) != 3 {
+		t.Errorf("expected 3 cached timings, got %d", len(result.cached))
+	}
+
+	errFetch := errors.New("boom")
+	calls = 0
+	_, err = runBenchmark(4, func() error {
+		calls++
+		if calls == 2 {
+			return errFetch
+		}
+		return nil
+	})
+	if !errors.Is(err, errFetch) || calls != 2 {
+		t.Errorf("expected to stop at the failing fetch, got %v after %d calls", err, calls)
+	}
+}
+
+func TestWriteBenchmark(t *testing.T) {
+	result := benchmarkResult{
+		cold:   40 * time.Millisecond,
+		cached: []time.Duration{time.Millisecond, 3 * time.Millisecond},
+	}
+	if avg := result.average(); avg != 2*time.Millisecond {
+		t.Errorf("expected an average of 2ms, got %s", avg)
+	}
+
+	var buf bytes.Buffer
+	writeBenchmark(&buf, "fixture-lake", result, false)
+	for _, want := range []string{"(3 iterations)", "First fetch:    40ms", "Cached average: 2ms", "Speedup:        20.0x"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	writeBenchmark(&buf, "fixture-lake", result, true)
+	if !strings.Contains(buf.String(), "(already cached)") {
+		t.Errorf("expected a warm first fetch to be flagged, got:\n%s", buf.String())
+	}
+}
+
+func TestBenchmarkArea(t *testing.T) {
+	var notices bytes.Buffer
+	client := newTestClient(t, emptyAreaAPI, pokeapi.WithNotices(&notices))
+
+	// On a fresh session the first fetch misses, even though later ones hit.
+	result, warm, err := benchmarkArea(context.Background(), client, "fixture-lake", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if warm {
+		t.Error("expected a cold first fetch on a fresh session")
+	}
+	if len(result.cached) != 2 {
+		t.Errorf("expected 2 cached timings, got %d", len(result.cached))
+	}
+
+	_, warm, err = benchmarkArea(context.Background(), client, "fixture-lake", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !warm {
+		t.Error("expected a warm first fetch once the area is cached")
+	}
+
+	if notices.Len() != 0 {
+		t.Errorf("expected cache notices to be silenced, got %q", notices.String())
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/eqedos/repl/internal/cache"
+)
+
+// cacheDumpEntry describes one cached response in a cache dump.
+type cacheDumpEntry struct {
+	URL        string  `json:"url"`
+	Bytes      int     `json:"bytes"`
+	AgeSeconds float64 `json:"age_seconds"`
+}
+
+// cacheDump lists every entry in c, sorted by URL.
+func cacheDump(c *cache.Cache) []cacheDumpEntry {
+	var entries []cacheDumpEntry
+	c.Range(func(key string, data []byte, age time.Duration) bool {
+		entries = append(entries, cacheDumpEntry{
+			URL:        key,
+			Bytes:      len(data),
+			AgeSeconds: age.Round(time.Millisecond).Seconds(),
+		})
+		return true
+	})
+	slices.SortFunc(entries, func(a, b cacheDumpEntry) int {
+		return cmp.Compare(a.URL, b.URL)
+	})
+	return entries
+}
+
+// writeCacheDump writes a JSON summary of every entry in c to path.
+func writeCacheDump(path string, c *cache.Cache) error {
+	entries := cacheDump(c)
+	if entries == nil {
+		entries = []cacheDumpEntry{}
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cache dump: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cache dump: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestShutdownDumpsCache(t *testing.T) {
+	// The embedded snapshot preloads the cache, so no network is needed.
+	client := pokeapi.NewClient(pokeapi.WithOffline())
+	dir := t.TempDir()
+	cfg := &config{
+		client:   client,
+		pokedex:  map[string]pokeapi.Pokemon{"pikachu": {Name: "pikachu"}},
+		savePath: filepath.Join(dir, "save.json"),
+		dumpPath: filepath.Join(dir, "cache.json"),
+	}
+
+	cfg.shutdown()
+
+	data, err := os.ReadFile(cfg.dumpPath)
+	if err != nil {
+		t.Fatalf("failed to read cache dump: %v", err)
+	}
+	var entries []cacheDumpEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("cache dump is not valid JSON: %v", err)
+	}
+
+	expected := []string{
+		pokeapi.BaseURL + "/location-area/",
+		pokeapi.BaseURL + "/pokemon/charizard/",
+		pokeapi.BaseURL + "/pokemon/pikachu/",
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, entry := range entries {
+		if entry.URL != expected[i] {
+			t.Errorf("entry %d: expected %s, got %s", i, expected[i], entry.URL)
+		}
+		if data, _, _ := client.Cache().GetWithAge(entry.URL); entry.Bytes != len(data) {
+			t.Errorf("%s: expected %d bytes, got %d", entry.URL, len(data), entry.Bytes)
+		}
+		if entry.AgeSeconds < 0 {
+			t.Errorf("%s: unexpected negative age", entry.URL)
+		}
+	}
+
+	// The save is written in the same cleanup path.
+	if _, err := os.Stat(cfg.savePath); err != nil {
+		t.Errorf("expected the Pokedex to be saved: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// Catch models selectable with the -catch-model flag.
+const (
+	catchModelBaseExp = "base-exp"
+	catchModelSpecies = "species"
+)
+
+// maxCaptureRate is the highest capture rate a species can have.
+const maxCaptureRate = 255
+
+// maxBaseExp caps the base experience used for catch rolls. Base experience
+// ranges from ~36 (low) to ~608 (legendary); anything at or above the cap is
+// treated as equally hard to catch.
+const maxBaseExp = 400
+
+// catchRoll performs a single catch attempt for a Pokemon with the given base
+// experience. Higher base experience makes the Pokemon harder to catch: a
+// random number in [0, maxBaseExp) must reach the capped base experience.
+func catchRoll(rng *rand.Rand, baseExperience int) bool {
+	catchThreshold := min(baseExperience, maxBaseExp)
+	return rng.Intn(maxBaseExp) >= catchThreshold
+}
+
+// speciesCatchRoll performs a single catch attempt using a species' capture
+// rate (0-255, higher is easier), as in the games: a random number in
+// [0, 256) must fall below the capture rate.
+func speciesCatchRoll(rng *rand.Rand, captureRate int) bool {
+	return rng.Intn(maxCaptureRate+1) < captureRate
+}
+
+// validateCatchModel reports an error for unknown catch models.
+func validateCatchModel(model string) error {
+	switch model {
+	case catchModelBaseExp, catchModelSpecies:
+		return nil
+	default:
+		return fmt.Errorf("invalid catch model %q (valid: %s, %s)", model, catchModelBaseExp, catchModelSpecies)
+	}
+}
+
+// catchRollFor returns a catch attempt for pokemon under the configured catch
+// model. The species model falls back to base experience when the species
+// data cannot be loaded.
+func (cfg *config) catchRollFor(pokemon *pokeapi.Pokemon) func() bool {
+	if cfg.catchModel == catchModelSpecies {
+		species, err := cfg.client.GetPokemonSpeciesContext(cfg.commandContext(), cmp.Or(pokemon.Species.Name, pokemon.Name))
+		if err == nil {
+			return func() bool { return speciesCatchRoll(cfg.rng, species.CaptureRate) }
+		}
+		fmt.Printf("Could not load species data (%v); using base experience instead.\n", err)
+	}
+	return func() bool { return catchRoll(cfg.rng, pokemon.BaseExperience) }
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestCatchRoll(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 100 {
+		if !catchRoll(rng, 0) {
+			t.Fatal("expected a Pokemon with no base experience to always be caught")
+		}
+		if catchRoll(rng, 608) {
+			t.Fatal("expected a Pokemon above the cap to never be caught")
+		}
+	}
+}
+
+func TestSpeciesCatchRoll(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 100 {
+		if !speciesCatchRoll(rng, maxCaptureRate+1) {
+			t.Fatal("expected a capture rate above every roll to always catch")
+		}
+		if speciesCatchRoll(rng, 0) {
+			t.Fatal("expected a capture rate of 0 to never catch")
+		}
+	}
+
+	// A capture rate of 3 (legendaries) should catch far less often than 255.
+	rare, common := 0, 0
+	for range 10000 {
+		if speciesCatchRoll(rng, 3) {
+			rare++
+		}
+		if speciesCatchRoll(rng, 255) {
+			common++
+		}
+	}
+	if rare >= common || rare > 300 || common < 9900 {
+		t.Errorf("unexpected catch counts: rate 3 caught %d, rate 255 caught %d", rare, common)
+	}
+}
+
+func TestValidateCatchModel(t *testing.T) {
+	for _, model := range []string{catchModelBaseExp, catchModelSpecies} {
+		if err := validateCatchModel(model); err != nil {
+			t.Errorf("expected %q to be valid, got %v", model, err)
+		}
+	}
+	if err := validateCatchModel("luck"); err == nil {
+		t.Error("expected an error for an unknown model")
+	}
+}
+
+func TestCatchTimes(t *testing.T) {
+	const seed = 7
+	const baseExp = 112
+
+	// Replay the seed to find the attempt on which pikachu is first caught.
+	replay := rand.New(rand.NewSource(seed))
+	firstCatch := 1
+	for !catchRoll(replay, baseExp) {
+		firstCatch++
+	}
+
+	catchWithTimes := func(times int) bool {
+		cfg := &config{
+			client:  newTestClient(t, pokemonAPI(baseExp)),
+			pokedex: make(map[string]pokeapi.Pokemon),
+			rng:     rand.New(rand.NewSource(seed)),
+		}
+		if err := commandCatch(cfg, []string{"pikachu", "--times", strconv.Itoa(times)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, ok := cfg.pokedex["pikachu"]
+		return ok
+	}
+
+	if firstCatch > 1 && catchWithTimes(firstCatch-1) {
+		t.Errorf("expected no catch within %d attempts", firstCatch-1)
+	}
+	if !catchWithTimes(firstCatch) {
+		t.Errorf("expected a catch within %d attempts", firstCatch)
+	}
+
+	cfg := &config{pokedex: make(map[string]pokeapi.Pokemon)}
+	if err := commandCatch(cfg, []string{"pikachu", "--times", "0"}); err == nil {
+		t.Error("expected an error for --times 0")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// catchSummary records the outcome of a bulk catch.
+type catchSummary struct {
+	caught  []string
+	escaped []string
+	skipped []string
+	failed  []string
+}
+
+// encounterNames returns the names of the Pokemon encountered in area, in API
+// order and without duplicates.
+func encounterNames(area *pokeapi.LocationAreaResponse) []string {
+	var names []string
+	for _, encounter := range area.PokemonEncounters {
+		if !slices.Contains(names, encounter.Pokemon.Name) {
+			names = append(names, encounter.Pokemon.Name)
+		}
+	}
+	return names
+}
+
+// hasType reports whether pokemon has the named type in its current typing.
+func hasType(pokemon *pokeapi.Pokemon, typeName string) bool {
+	return slices.ContainsFunc(pokemon.Types, func(t pokeapi.PokemonType) bool {
+		return t.Type.Name == typeName
+	})
+}
+
+// filterByType returns the Pokemon with the named type. Nil entries, which
+// mark Pokemon that failed to load, are skipped.
+func filterByType(pokemon []*pokeapi.Pokemon, typeName string) []*pokeapi.Pokemon {
+	var matches []*pokeapi.Pokemon
+	for _, p := range pokemon {
+		if p != nil && hasType(p, typeName) {
+			matches = append(matches, p)
+		}
+	}
+	return matches
+}
+
+// writeCatchSummary prints how many Pokemon were caught, escaped, or skipped,
+// and which could not be loaded, if any.
+func writeCatchSummary(w io.Writer, s catchSummary) {
+	fmt.Fprintln(w, "Summary:")
+	writeSummaryLine(w, "Caught", s.caught)
+	writeSummaryLine(w, "Escaped", s.escaped)
+	writeSummaryLine(w, "Skipped", s.skipped)
+	if len(s.failed) > 0 {
+		writeSummaryLine(w, "Failed", s.failed)
+	}
+}
+
+// writeSummaryLine prints a count followed by the names it covers, if any.
+func writeSummaryLine(w io.Writer, label string, names []string) {
+	if len(names) == 0 {
+		fmt.Fprintf(w, "  %s: 0\n", label)
+		return
+	}
+	fmt.Fprintf(w, "  %s: %d (%s)\n", label, len(names), strings.Join(names, ", "))
+}
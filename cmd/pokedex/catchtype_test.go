@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// typed returns a Pokemon with the given name and types.
+func typed(name string, types ...string) *pokeapi.Pokemon {
+	p := &pokeapi.Pokemon{Name: name}
+	for i, typeName := range types {
+		p.Types = append(p.Types, pokeapi.PokemonType{Slot: i + 1, Type: pokeapi.NamedResource{Name: typeName}})
+	}
+	return p
+}
+
+func TestFilterByType(t *testing.T) {
+	pokemon := []*pokeapi.Pokemon{
+		typed("tentacool", "water", "poison"),
+		nil, // failed to load
+		typed("hoothoot", "normal", "flying"),
+		typed("magikarp", "water"),
+	}
+
+	var names []string
+	for _, p := range filterByType(pokemon, "water") {
+		names = append(names, p.Name)
+	}
+	if expected := []string{"tentacool", "magikarp"}; !slices.Equal(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	if matches := filterByType(pokemon, "fire"); len(matches) != 0 {
+		t.Errorf("expected no fire types, got %d", len(matches))
+	}
+}
+
+func TestEncounterNames(t *testing.T) {
+	area := loadLocationArea(t, "location_area.json")
+	area.PokemonEncounters = append(area.PokemonEncounters, area.PokemonEncounters[0])
+
+	expected := []string{"tentacool", "hoothoot", "magikarp", "bidoof"}
+	if got := encounterNames(area); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWriteCatchSummary(t *testing.T) {
+	var buf bytes.Buffer
+	writeCatchSummary(&buf, catchSummary{
+		caught:  []string{"tentacool", "magikarp"},
+		skipped: []string{"psyduck"},
+	})
+
+	expected := "Summary:\n" +
+		"  Caught: 2 (tentacool, magikarp)\n" +
+		"  Escaped: 0\n" +
+		"  Skipped: 1 (psyduck)\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// Values accepted by the -color flag.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ANSI color codes used for highlighting output.
+const (
+	ansiRed   = "31"
+	ansiGreen = "32"
+)
+
+// colorEnabled decides whether output should use ANSI colors. In order of
+// precedence:
+//
+//  1. -color=always forces colors on.
+//  2. -color=never (or -no-color) turns colors off.
+//  3. A set NO_COLOR environment variable, whatever its value, turns colors off.
+//  4. Otherwise colors are used only when stdout is a terminal.
+func colorEnabled(mode string, noColorEnv, stdoutTTY bool) (bool, error) {
+	switch mode {
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	case colorAuto, "":
+		return !noColorEnv && stdoutTTY, nil
+	default:
+		return false, fmt.Errorf("invalid color mode %q (valid: %s, %s, %s)", mode, colorAuto, colorAlways, colorNever)
+	}
+}
+
+// colorize wraps s in the given ANSI color when colors are enabled.
+func (cfg *config) colorize(code, s string) string {
+	if !cfg.color {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestColorEnabled(t *testing.T) {
+	testCases := []struct {
+		name       string
+		mode       string
+		noColorEnv bool
+		tty        bool
+		expected   bool
+	}{
+		{name: "auto on a terminal", mode: colorAuto, tty: true, expected: true},
+		{name: "auto when piped", mode: colorAuto, tty: false, expected: false},
+		{name: "NO_COLOR disables auto", mode: colorAuto, noColorEnv: true, tty: true, expected: false},
+		{name: "never on a terminal", mode: colorNever, tty: true, expected: false},
+		{name: "always when piped", mode: colorAlways, tty: false, expected: true},
+		{name: "always overrides NO_COLOR", mode: colorAlways, noColorEnv: true, expected: true},
+		{name: "empty mode behaves like auto", mode: "", tty: true, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := colorEnabled(tc.mode, tc.noColorEnv, tc.tty)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	if _, err := colorEnabled("sometimes", false, true); err == nil {
+		t.Error("expected an error for an invalid mode")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import "github.com/eqedos/repl/internal/pokeapi"
+
+// averageBaseStats holds the approximate mean base stats across all Pokemon,
+// keyed by stat name. Fetching every Pokemon to compute these at runtime is
+// impractical, so they are bundled here.
+var averageBaseStats = map[string]int{
+	"hp":              70,
+	"attack":          80,
+	"defense":         74,
+	"special-attack":  73,
+	"special-defense": 72,
+	"speed":           68,
+}
+
+// statDelta describes how a single base stat compares to the average.
+type statDelta struct {
+	name    string
+	value   int
+	average int
+	delta   int
+}
+
+// compareToAverage returns the difference between each of the given base stats
+// and the bundled average, in the same order. Stats without a known average are skipped.
+func compareToAverage(stats []pokeapi.PokemonStat) []statDelta {
+	deltas := make([]statDelta, 0, len(stats))
+	for _, stat := range stats {
+		avg, ok := averageBaseStats[stat.Stat.Name]
+		if !ok {
+			continue
+		}
+		deltas = append(deltas, statDelta{
+			name:    stat.Stat.Name,
+			value:   stat.BaseStat,
+			average: avg,
+			delta:   stat.BaseStat - avg,
+		})
+	}
+	return deltas
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestCompareToAverage(t *testing.T) {
+	stats := []pokeapi.PokemonStat{
+		{BaseStat: 35, Stat: pokeapi.NamedResource{Name: "hp"}},
+		{BaseStat: 80, Stat: pokeapi.NamedResource{Name: "attack"}},
+		{BaseStat: 90, Stat: pokeapi.NamedResource{Name: "speed"}},
+		{BaseStat: 10, Stat: pokeapi.NamedResource{Name: "accuracy"}},
+	}
+
+	expected := []statDelta{
+		{name: "hp", value: 35, average: 70, delta: -35},
+		{name: "attack", value: 80, average: 80, delta: 0},
+		{name: "speed", value: 90, average: 68, delta: 22},
+	}
+
+	got := compareToAverage(stats)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d deltas, got %d: %+v", len(expected), len(got), got)
+	}
+	for i, want := range expected {
+		if got[i] != want {
+			t.Errorf("delta %d: expected %+v, got %+v", i, want, got[i])
+		}
+	}
+}
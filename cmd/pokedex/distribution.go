@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Histogram layout for stats --distribution.
+const (
+	// bucketWidth is the range of stat values covered by each bucket.
+	bucketWidth = 20
+
+	// maxBarWidth is the longest bar drawn; larger counts are scaled down.
+	maxBarWidth = 40
+)
+
+// bucket counts the stat values within an inclusive range.
+type bucket struct {
+	low   int
+	high  int
+	count int
+}
+
+// bucketize groups values into consecutive buckets of width: 0-width,
+// width+1-2*width, and so on, up to the bucket holding the largest value.
+// Empty buckets in between are kept so the histogram has no gaps.
+func bucketize(values []int, width int) []bucket {
+	if len(values) == 0 {
+		return nil
+	}
+
+	index := func(v int) int { return max(v-1, 0) / width }
+
+	top := 0
+	for _, v := range values {
+		top = max(top, index(v))
+	}
+
+	buckets := make([]bucket, top+1)
+	for i := range buckets {
+		buckets[i] = bucket{low: i*width + 1, high: (i + 1) * width}
+	}
+	buckets[0].low = 0
+	for _, v := range values {
+		buckets[index(v)].count++
+	}
+	return buckets
+}
+
+// writeHistogram renders buckets as labelled bars of "#", scaling the bars
+// down when the largest count exceeds maxBarWidth.
+func writeHistogram(w io.Writer, stat string, total int, buckets []bucket) {
+	fmt.Fprintf(w, "Distribution of %s across %d Pokemon:\n", stat, total)
+
+	peak := 0
+	for _, b := range buckets {
+		peak = max(peak, b.count)
+	}
+
+	for _, b := range buckets {
+		bar := b.count
+		if peak > maxBarWidth {
+			// Round up so non-empty buckets always show at least one mark.
+			bar = (b.count*maxBarWidth + peak - 1) / peak
+		}
+		fmt.Fprintf(w, "  %3d-%-3d | %-*s %d\n", b.low, b.high, min(peak, maxBarWidth), strings.Repeat("#", bar), b.count)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestBucketize(t *testing.T) {
+	buckets := bucketize([]int{0, 20, 21, 40, 45, 90, 100}, 20)
+
+	expected := []bucket{
+		{low: 0, high: 20, count: 2},
+		{low: 21, high: 40, count: 2},
+		{low: 41, high: 60, count: 1},
+		{low: 61, high: 80, count: 0},
+		{low: 81, high: 100, count: 2},
+	}
+	if !slices.Equal(buckets, expected) {
+		t.Errorf("expected %+v, got %+v", expected, buckets)
+	}
+
+	if buckets := bucketize(nil, 20); buckets != nil {
+		t.Errorf("expected no buckets for no values, got %+v", buckets)
+	}
+}
+
+func TestWriteHistogramGolden(t *testing.T) {
+	values := []int{35, 39, 45, 50, 55, 60, 78, 80, 95, 106}
+
+	var buf bytes.Buffer
+	writeHistogram(&buf, "hp", len(values), bucketize(values, bucketWidth))
+	checkGolden(t, "distribution.golden", buf.Bytes())
+}
+
+func TestWriteHistogramScalesBars(t *testing.T) {
+	values := make([]int, 0, 81)
+	for range 80 {
+		values = append(values, 50)
+	}
+	values = append(values, 100)
+
+	var buf bytes.Buffer
+	writeHistogram(&buf, "speed", len(values), bucketize(values, bucketWidth))
+	checkGolden(t, "distribution_scaled.golden", buf.Bytes())
+}
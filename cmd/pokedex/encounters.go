@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// conditionDescriptions maps encounter condition values to readable phrases.
+var conditionDescriptions = map[string]string{
+	"time-morning":  "only in the morning",
+	"time-day":      "only during the day",
+	"time-night":    "only at night",
+	"season-spring": "only in spring",
+	"season-summer": "only in summer",
+	"season-autumn": "only in autumn",
+	"season-winter": "only in winter",
+	"swarm-yes":     "only during a swarm",
+	"swarm-no":      "outside of swarms",
+	"radar-on":      "only with the Poke Radar",
+	"radar-off":     "without the Poke Radar",
+}
+
+// describeCondition returns a readable phrase for an encounter condition value,
+// falling back to its API name.
+func describeCondition(name string) string {
+	if desc, ok := conditionDescriptions[name]; ok {
+		return desc
+	}
+	return name
+}
+
+// encounterLines describes each distinct way a Pokemon can be encountered,
+// merging identical summaries that appear in several game versions.
+func encounterLines(summaries []pokeapi.EncounterSummary) []string {
+	var lines []string
+	for _, summary := range summaries {
+		line := fmt.Sprintf("lv %d-%d, %s, %d%%", summary.MinLevel, summary.MaxLevel, summary.Method, summary.Chance)
+		if len(summary.Conditions) > 0 {
+			conditions := make([]string, len(summary.Conditions))
+			for i, c := range summary.Conditions {
+				conditions[i] = describeCondition(c)
+			}
+			line += " (" + strings.Join(conditions, ", ") + ")"
+		}
+		if !slices.Contains(lines, line) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// loadLocationArea reads a location area fixture from testdata.
+func loadLocationArea(t *testing.T, name string) *pokeapi.LocationAreaResponse {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	var area pokeapi.LocationAreaResponse
+	if err := json.Unmarshal(data, &area); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	return &area
+}
+
+func TestEncounterLines(t *testing.T) {
+	area := loadLocationArea(t, "location_area.json")
+
+	expected := map[string][]string{
+		"tentacool": {"lv 20-30, surf, 60%"},
+		"hoothoot": {
+			"lv 10-12, walk, 20% (only at night)",
+			"lv 11-13, walk, 10% (only in the morning)",
+		},
+		"magikarp": {
+			"lv 3-15, old-rod, 100%",
+			"lv 10-25, good-rod, 55%",
+		},
+		"bidoof": {"lv 2-4, walk, 40% (only during a swarm)"},
+	}
+
+	byName := make(map[string][]pokeapi.EncounterSummary)
+	for _, summary := range pokeapi.SummarizeEncounters(area) {
+		byName[summary.PokemonName] = append(byName[summary.PokemonName], summary)
+	}
+
+	for name := range expected {
+		got := encounterLines(byName[name])
+		if !slices.Equal(got, expected[name]) {
+			t.Errorf("%s: expected %q, got %q", name, expected[name], got)
+		}
+	}
+}
+
+func TestDescribeConditionFallback(t *testing.T) {
+	if got := describeCondition("slot2-ruby"); got != "slot2-ruby" {
+		t.Errorf("expected unknown conditions to fall back to their name, got %q", got)
+	}
+}
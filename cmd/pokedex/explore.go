@@ -0,0 +1,126 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// exploreOptions controls how an explored area is displayed.
+type exploreOptions struct {
+	// apiOrder keeps encounters in the order returned by the API instead of
+	// sorting them alphabetically.
+	apiOrder bool
+
+	// details adds level, method, chance, and condition lines per Pokemon.
+	details bool
+
+	// levels restricts the listing to encounters within a level range.
+	levels levelRange
+
+	// limit caps how many Pokemon a detailed listing shows; zero shows all.
+	limit int
+}
+
+// levelRange is an inclusive range of levels; a zero bound is unbounded.
+type levelRange struct {
+	min int
+	max int
+}
+
+// validate reports an error for negative bounds or a minimum above the maximum.
+func (r levelRange) validate() error {
+	if r.min < 0 || r.max < 0 {
+		return fmt.Errorf("levels must not be negative")
+	}
+	if r.max > 0 && r.min > r.max {
+		return fmt.Errorf("--min-level (%d) must not be greater than --max-level (%d)", r.min, r.max)
+	}
+	return nil
+}
+
+// overlaps reports whether the levels from lo to hi fall at least partly within the range.
+func (r levelRange) overlaps(lo, hi int) bool {
+	return (r.min == 0 || hi >= r.min) && (r.max == 0 || lo <= r.max)
+}
+
+// filterByLevel returns the encounters that can occur within levels. Each
+// returned encounter keeps only its encounter details that overlap the range.
+func filterByLevel(encounters []pokeapi.PokemonEncounter, levels levelRange) []pokeapi.PokemonEncounter {
+	if levels == (levelRange{}) {
+		return encounters
+	}
+
+	var filtered []pokeapi.PokemonEncounter
+	for _, encounter := range encounters {
+		var versions []pokeapi.VersionEncounterGroup
+		for _, version := range encounter.VersionDetails {
+			var details []pokeapi.EncounterDetail
+			for _, detail := range version.EncounterDetails {
+				if levels.overlaps(detail.MinLevel, detail.MaxLevel) {
+					details = append(details, detail)
+				}
+			}
+			if len(details) > 0 {
+				version.EncounterDetails = details
+				versions = append(versions, version)
+			}
+		}
+		if len(versions) > 0 {
+			encounter.VersionDetails = versions
+			filtered = append(filtered, encounter)
+		}
+	}
+	return filtered
+}
+
+// writeExplore writes the listing for an explored area to w and returns the
+// names of the listed Pokemon in display order. Detailed listings are built
+// from the area's encounter summaries.
+func writeExplore(w io.Writer, area *pokeapi.LocationAreaResponse, opts exploreOptions) []string {
+	fmt.Fprintf(w, "Exploring %s...\n", area.Location.Name)
+	fmt.Fprintln(w, "Found Pokemon:")
+
+	encounters := filterByLevel(area.PokemonEncounters, opts.levels)
+	if len(encounters) == 0 {
+		fmt.Fprintln(w, "  No Pokemon found in this area.")
+		return nil
+	}
+
+	if !opts.apiOrder {
+		encounters = slices.Clone(encounters)
+		slices.SortStableFunc(encounters, func(a, b pokeapi.PokemonEncounter) int {
+			return cmp.Compare(a.Pokemon.Name, b.Pokemon.Name)
+		})
+	}
+
+	hidden := 0
+	if opts.details {
+		encounters, hidden = truncateList(encounters, opts.limit)
+	}
+
+	summaries := make(map[string][]pokeapi.EncounterSummary)
+	if opts.details {
+		shown := *area
+		shown.PokemonEncounters = encounters
+		for _, summary := range pokeapi.SummarizeEncounters(&shown) {
+			summaries[summary.PokemonName] = append(summaries[summary.PokemonName], summary)
+		}
+	}
+
+	names := make([]string, 0, len(encounters))
+	for _, encounter := range encounters {
+		fmt.Fprintf(w, "  - %s\n", encounter.Pokemon.Name)
+		if opts.details {
+			for _, line := range encounterLines(summaries[encounter.Pokemon.Name]) {
+				fmt.Fprintf(w, "      %s\n", line)
+			}
+		}
+		names = append(names, encounter.Pokemon.Name)
+	}
+	writeTruncationNotice(w, hidden)
+	return names
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"flag"
+	"os"
+	"slices"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// checkGolden compares got with the named golden file, rewriting it when -update is set.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := "testdata/" + name
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestWriteExploreGolden(t *testing.T) {
+	testCases := []struct {
+		golden string
+		// fixture is the location area to explore; location_area.json if empty.
+		fixture string
+		opts    exploreOptions
+		names   []string
+	}{
+		{
+			golden: "explore.golden",
+			names:  []string{"bidoof", "hoothoot", "magikarp", "tentacool"},
+		},
+		{
+			golden: "explore_api_order.golden",
+			opts:   exploreOptions{apiOrder: true},
+			names:  []string{"tentacool", "hoothoot", "magikarp", "bidoof"},
+		},
+		{
+			golden: "explore_details.golden",
+			opts:   exploreOptions{details: true},
+			names:  []string{"bidoof", "hoothoot", "magikarp", "tentacool"},
+		},
+		{
+			golden: "explore_truncated.golden",
+			opts:   exploreOptions{details: true, limit: 2},
+			names:  []string{"bidoof", "hoothoot"},
+		},
+		{
+			golden: "explore_levels.golden",
+			opts:   exploreOptions{details: true, levels: levelRange{min: 10, max: 12}},
+			names:  []string{"hoothoot", "magikarp"},
+		},
+		{
+			golden: "explore_no_matches.golden",
+			opts:   exploreOptions{levels: levelRange{min: 50}},
+			names:  nil,
+		},
+		{
+			// Slots sharing a method and version are listed one per line.
+			golden:  "explore_slots.golden",
+			fixture: "location_area_slots.json",
+			opts:    exploreOptions{details: true},
+			names:   []string{"geodude", "onix", "zubat"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.golden, func(t *testing.T) {
+			area := loadLocationArea(t, cmp.Or(tc.fixture, "location_area.json"))
+
+			var buf bytes.Buffer
+			names := writeExplore(&buf, area, tc.opts)
+
+			checkGolden(t, tc.golden, buf.Bytes())
+			if !slices.Equal(names, tc.names) {
+				t.Errorf("expected names %v, got %v", tc.names, names)
+			}
+		})
+	}
+}
+
+func TestLevelRange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		levels   levelRange
+		lo, hi   int
+		overlaps bool
+	}{
+		{name: "unbounded", levels: levelRange{}, lo: 1, hi: 100, overlaps: true},
+		{name: "inside", levels: levelRange{min: 10, max: 20}, lo: 12, hi: 15, overlaps: true},
+		{name: "partly below", levels: levelRange{min: 10, max: 20}, lo: 5, hi: 10, overlaps: true},
+		{name: "partly above", levels: levelRange{min: 10, max: 20}, lo: 20, hi: 30, overlaps: true},
+		{name: "entirely below", levels: levelRange{min: 10, max: 20}, lo: 2, hi: 9, overlaps: false},
+		{name: "entirely above", levels: levelRange{min: 10, max: 20}, lo: 21, hi: 30, overlaps: false},
+		{name: "min only", levels: levelRange{min: 30}, lo: 20, hi: 29, overlaps: false},
+		{name: "max only", levels: levelRange{max: 5}, lo: 2, hi: 4, overlaps: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.levels.overlaps(tc.lo, tc.hi); got != tc.overlaps {
+				t.Errorf("expected %v, got %v", tc.overlaps, got)
+			}
+		})
+	}
+
+	if err := (levelRange{min: 20, max: 10}).validate(); err == nil {
+		t.Error("expected an error when min is greater than max")
+	}
+	if err := (levelRange{min: -1}).validate(); err == nil {
+		t.Error("expected an error for a negative level")
+	}
+	if err := (levelRange{min: 20}).validate(); err != nil {
+		t.Errorf("expected a min-only range to be valid, got %v", err)
+	}
+}
+
+func TestExploreWithoutMatchesResetsLastList(t *testing.T) {
+	cfg := &config{
+		client:   newTestClient(t, emptyAreaAPI),
+		lastList: []string{"canalave-city-area"},
+	}
+
+	if err := commandExplore(cfg, []string{"fixture-lake"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.lastList) != 0 {
+		t.Errorf("expected the previous list to be cleared, got %v", cfg.lastList)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// fakeAPI answers every request with the body respond returns for it, with
+// status 200. An error from respond is returned by RoundTrip instead, so
+// respond can also block until the request is cancelled to simulate a
+// stalled API.
+type fakeAPI func(req *http.Request) (string, error)
+
+func (respond fakeAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := respond(req)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// fixtureAPI answers every request with the named file from testdata.
+func fixtureAPI(t *testing.T, fixture string) fakeAPI {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return func(*http.Request) (string, error) {
+		return string(data), nil
+	}
+}
+
+// emptyAreaAPI answers every request with a location area without Pokemon.
+var emptyAreaAPI = fakeAPI(func(*http.Request) (string, error) {
+	return `{"name": "fixture-lake"}`, nil
+})
+
+// pokemonAPI answers every request with a Pokemon named after the last
+// element of the request path, with the given base experience. A base
+// experience of 0 is always caught.
+func pokemonAPI(baseExp int) fakeAPI {
+	return func(req *http.Request) (string, error) {
+		return pokemonBody(req, baseExp), nil
+	}
+}
+
+// pokemonBody is the body pokemonAPI answers req with.
+func pokemonBody(req *http.Request, baseExp int) string {
+	return fmt.Sprintf(`{"name": %q, "base_experience": %d}`, path.Base(req.URL.Path), baseExp)
+}
+
+// stall blocks until req is cancelled and returns the cancellation error.
+func stall(req *http.Request) (string, error) {
+	<-req.Context().Done()
+	return "", req.Context().Err()
+}
+
+// newTestClient returns a client that sends every request to rt, without the
+// embedded snapshot and with notices discarded. opts are applied last.
+func newTestClient(t *testing.T, rt http.RoundTripper, opts ...pokeapi.Option) *pokeapi.Client {
+	t.Helper()
+	opts = append([]pokeapi.Option{pokeapi.WithTransport(rt), pokeapi.WithoutSnapshot(), pokeapi.WithNotices(io.Discard)}, opts...)
+	return pokeapi.NewClient(opts...)
+}
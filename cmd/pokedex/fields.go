@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// pokemonFields maps the non-stat field names accepted by inspect --fields
+// to their values. Stat names are resolved separately against Pokemon.Stats.
+var pokemonFields = map[string]func(*pokeapi.Pokemon) string{
+	"name":            func(p *pokeapi.Pokemon) string { return p.Name },
+	"id":              func(p *pokeapi.Pokemon) string { return strconv.Itoa(p.ID) },
+	"height":          func(p *pokeapi.Pokemon) string { return strconv.Itoa(p.Height) },
+	"weight":          func(p *pokeapi.Pokemon) string { return strconv.Itoa(p.Weight) },
+	"base_experience": func(p *pokeapi.Pokemon) string { return strconv.Itoa(p.BaseExperience) },
+	"types": func(p *pokeapi.Pokemon) string {
+		types := make([]string, len(p.Types))
+		for i, t := range p.Types {
+			types[i] = t.Type.Name
+		}
+		return strings.Join(types, ",")
+	},
+}
+
+// pokemonFieldOrder lists the non-stat fields in the order shown in help messages.
+var pokemonFieldOrder = []string{"name", "id", "height", "weight", "base_experience", "types"}
+
+// selectFields returns the requested fields of a Pokemon as "key=value" lines.
+// Field names may be any of pokemonFields or the name of one of its stats.
+func selectFields(pokemon *pokeapi.Pokemon, fields []string) ([]string, error) {
+	lines := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if get, ok := pokemonFields[field]; ok {
+			lines = append(lines, field+"="+get(pokemon))
+			continue
+		}
+
+		value, ok := statValue(pokemon, field)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (valid fields: %s)", field, strings.Join(validFields(pokemon), ", "))
+		}
+		lines = append(lines, fmt.Sprintf("%s=%d", field, value))
+	}
+	return lines, nil
+}
+
+// statValue returns the base value of the named stat.
+func statValue(pokemon *pokeapi.Pokemon, name string) (int, bool) {
+	for _, stat := range pokemon.Stats {
+		if stat.Stat.Name == name {
+			return stat.BaseStat, true
+		}
+	}
+	return 0, false
+}
+
+// validFields lists every field name accepted for the given Pokemon.
+func validFields(pokemon *pokeapi.Pokemon) []string {
+	fields := append([]string{}, pokemonFieldOrder...)
+	for _, stat := range pokemon.Stats {
+		fields = append(fields, stat.Stat.Name)
+	}
+	return fields
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestSelectFields(t *testing.T) {
+	pokemon := &pokeapi.Pokemon{
+		ID:     25,
+		Name:   "pikachu",
+		Height: 4,
+		Weight: 60,
+		Stats: []pokeapi.PokemonStat{
+			{BaseStat: 35, Stat: pokeapi.NamedResource{Name: "hp"}},
+			{BaseStat: 90, Stat: pokeapi.NamedResource{Name: "speed"}},
+		},
+		Types: []pokeapi.PokemonType{{Slot: 1, Type: pokeapi.NamedResource{Name: "electric"}}},
+	}
+
+	got, err := selectFields(pokemon, []string{"name", "weight", "hp", "types"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"name=pikachu", "weight=60", "hp=35", "types=electric"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	_, err = selectFields(pokemon, []string{"name", "colour"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	for _, want := range []string{`"colour"`, "weight", "speed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got %q", want, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"os"
+)
+
+// scannedLine is the outcome of one scan of the REPL input.
+type scannedLine struct {
+	text string
+	ok   bool
+}
+
+// readLine reads the next line from cfg.in, giving up when cfg.ctx is done.
+// The scan runs on a separate goroutine so that waiting for input does not
+// block signal handling; a scan abandoned this way is picked up by the next
+// call, so cfg.in is never read by two goroutines at once. It reports false
+// at the end of input or when cfg.ctx is done.
+func (cfg *config) readLine() (string, bool) {
+	if cfg.in == nil {
+		return "", false
+	}
+	if cfg.pendingLine == nil {
+		pending := make(chan scannedLine, 1)
+		cfg.pendingLine = pending
+		go func() {
+			ok := cfg.in.Scan()
+			pending <- scannedLine{text: cfg.in.Text(), ok: ok}
+		}()
+	}
+
+	select {
+	case line := <-cfg.pendingLine:
+		cfg.pendingLine = nil
+		return line.text, line.ok
+	case <-cfg.commandContext().Done():
+		return "", false
+	}
+}
+
+// signalContext returns a context that is cancelled when a signal arrives on
+// signals. The returned stop function stops watching and returns the signal
+// that was received, or nil if there was none.
+func signalContext(signals <-chan os.Signal) (context.Context, func() os.Signal) {
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case sig := <-signals:
+			received <- sig
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return ctx, func() os.Signal {
+		close(done)
+		<-exited
+		cancel()
+		// A signal that arrived just as watching stopped is still reported.
+		select {
+		case sig := <-received:
+			return sig
+		case sig := <-signals:
+			return sig
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// newSignalConfig returns a quiet config that reads input and whose client
+// sends sig on signals when signalAt is fetched.
+func newSignalConfig(t *testing.T, input string, signalAt string, sig os.Signal, signals chan os.Signal) *config {
+	// Every Pokemon is always caught.
+	rt := fakeAPI(func(req *http.Request) (string, error) {
+		if path.Base(req.URL.Path) == signalAt {
+			signals <- sig
+		}
+		return pokemonBody(req, 0), nil
+	})
+	return &config{
+		client:  newTestClient(t, rt),
+		pokedex: make(map[string]pokeapi.Pokemon),
+		in:      bufio.NewScanner(strings.NewReader(input)),
+		rng:     rand.New(rand.NewSource(1)),
+		quiet:   true,
+	}
+}
+
+func TestReplRunsUntilEndOfInput(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	cfg := newSignalConfig(t, "catch bulbasaur\ncatch ivysaur\n", "", nil, signals)
+
+	if sig := repl(cfg, signals); sig != nil {
+		t.Errorf("expected no signal at the end of input, got %v", sig)
+	}
+	if len(cfg.pokedex) != 2 {
+		t.Errorf("expected both Pokemon to be caught, got %d", len(cfg.pokedex))
+	}
+}
+
+func TestReplInterruptAtPrompt(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	// The pipe is never written to, so the REPL waits at the prompt.
+	r, w := io.Pipe()
+	defer w.Close()
+	cfg := newSignalConfig(t, "", "", nil, signals)
+	cfg.in = bufio.NewScanner(r)
+
+	signals <- os.Interrupt
+	if sig := repl(cfg, signals); sig != os.Interrupt {
+		t.Errorf("expected Ctrl-C at the prompt to stop the REPL, got %v", sig)
+	}
+}
+
+func TestReplSignalsDuringCommand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sig      os.Signal
+		expected os.Signal
+		caught   int
+	}{
+		// Ctrl-C only cancels the running command; the REPL keeps going.
+		{name: "interrupt", sig: os.Interrupt, expected: nil, caught: 2},
+		// Termination stops the REPL once the running command returns.
+		{name: "terminate", sig: syscall.SIGTERM, expected: syscall.SIGTERM, caught: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signals := make(chan os.Signal, 1)
+			cfg := newSignalConfig(t, "catch bulbasaur\ncatch ivysaur\n", "bulbasaur", tc.sig, signals)
+
+			if sig := repl(cfg, signals); sig != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, sig)
+			}
+			if len(cfg.pokedex) != tc.caught {
+				t.Errorf("expected %d Pokemon caught, got %d", tc.caught, len(cfg.pokedex))
+			}
+		})
+	}
+}
+
+func TestReplSignalAbandonsStalledRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sig      os.Signal
+		expected os.Signal
+	}{
+		{name: "interrupt", sig: os.Interrupt, expected: nil},
+		{name: "terminate", sig: syscall.SIGTERM, expected: syscall.SIGTERM},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signals := make(chan os.Signal, 1)
+			cfg := newSignalConfig(t, "catch bulbasaur\n", "", nil, signals)
+			// The signal arrives while the request waits for an answer that never comes.
+			cfg.client = newTestClient(t, fakeAPI(func(req *http.Request) (string, error) {
+				signals <- tc.sig
+				return stall(req)
+			}))
+
+			done := make(chan os.Signal, 1)
+			go func() { done <- repl(cfg, signals) }()
+
+			select {
+			case sig := <-done:
+				if sig != tc.expected {
+					t.Errorf("expected %v, got %v", tc.expected, sig)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the signal to abandon the stalled request")
+			}
+			if len(cfg.pokedex) != 0 {
+				t.Errorf("expected nothing to be caught, got %d", len(cfg.pokedex))
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"cmp"
+	"context"
+)
+
+// interruptible returns a context for a batch command that is cancelled when
+// the user presses Ctrl-C: the REPL cancels cfg.ctx on Ctrl-C while a command
+// runs. Batch commands use it to stop early and report partial results.
+func (cfg *config) interruptible() (context.Context, context.CancelFunc) {
+	return context.WithCancel(cfg.commandContext())
+}
+
+// commandContext returns the context of the running command, which the REPL
+// cancels when a signal arrives. Commands send every request with it, so a
+// stalled request never outlives Ctrl-C or a termination signal.
+func (cfg *config) commandContext() context.Context {
+	return cmp.Or(cfg.ctx, context.Background())
+}
+
+// countLoaded returns how many entries of a batch result were loaded.
+func countLoaded[T any](items []*T) int {
+	n := 0
+	for _, item := range items {
+		if item != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"math/rand"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// cancelAPI answers every request like pokemonAPI(0), so every Pokemon is
+// always caught, and calls cancel when the named Pokemon is requested. With
+// stalled set, that request is then never answered.
+func cancelAPI(cancelAt string, cancel context.CancelFunc, stalled bool) fakeAPI {
+	return func(req *http.Request) (string, error) {
+		if path.Base(req.URL.Path) == cancelAt {
+			cancel()
+			if stalled {
+				return stall(req)
+			}
+		}
+		return pokemonBody(req, 0), nil
+	}
+}
+
+func TestImportTargetsInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(path, []byte("bulbasaur\nivysaur\nvenusaur\ncharmander\n"), 0o644); err != nil {
+		t.Fatalf("failed to write targets: %v", err)
+	}
+
+	rt := cancelAPI("ivysaur", cancel, false)
+	cfg := &config{
+		ctx:     ctx,
+		client:  newTestClient(t, rt),
+		pokedex: make(map[string]pokeapi.Pokemon),
+		rng:     rand.New(rand.NewSource(1)),
+	}
+
+	// Interrupting is not an error: the command returns cleanly.
+	if err := commandImportTargets(cfg, []string{path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Targets handled before the interrupt are kept; the rest are never tried.
+	for _, name := range []string{"bulbasaur", "ivysaur"} {
+		if _, ok := cfg.pokedex[name]; !ok {
+			t.Errorf("expected %s to be caught before the interrupt", name)
+		}
+	}
+	for _, name := range []string{"venusaur", "charmander"} {
+		if _, ok := cfg.pokedex[name]; ok {
+			t.Errorf("expected %s not to be attempted after the interrupt", name)
+		}
+	}
+}
+
+func TestImportTargetsAbandonsRequestInFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(path, []byte("bulbasaur\nivysaur\nvenusaur\n"), 0o644); err != nil {
+		t.Fatalf("failed to write targets: %v", err)
+	}
+
+	rt := cancelAPI("ivysaur", cancel, true)
+	cfg := &config{
+		ctx:     ctx,
+		client:  newTestClient(t, rt),
+		pokedex: make(map[string]pokeapi.Pokemon),
+		rng:     rand.New(rand.NewSource(1)),
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- commandImportTargets(cfg, []string{path}) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the request in flight to be abandoned")
+	}
+
+	if _, ok := cfg.pokedex["bulbasaur"]; !ok || len(cfg.pokedex) != 1 {
+		t.Errorf("expected only bulbasaur to be caught, got %v", slices.Sorted(maps.Keys(cfg.pokedex)))
+	}
+}
+
+func TestCountLoaded(t *testing.T) {
+	items := []*pokeapi.Pokemon{{Name: "a"}, nil, {Name: "b"}, nil}
+	if got := countLoaded(items); got != 2 {
+		t.Errorf("expected 2 loaded, got %d", got)
+	}
+}
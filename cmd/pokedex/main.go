@@ -4,20 +4,96 @@ package main
 
 import (
 	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"maps"
 	"math/rand"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/eqedos/repl/internal/pokeapi"
 )
 
 // config holds the application state.
 type config struct {
+	// ctx is the context of the running command. The REPL cancels it when
+	// a signal arrives; batch commands derive an interruptible context from it.
+	ctx context.Context
+
 	client  *pokeapi.Client
 	nextURL *string
 	prevURL *string
 	pokedex map[string]pokeapi.Pokemon
+
+	// in reads user input; it is shared with confirmation prompts so that
+	// answers are consumed from the same stream as commands. Read it with
+	// readLine.
+	in *bufio.Scanner
+
+	// pendingLine delivers the result of a scan of in that is still running.
+	pendingLine chan scannedLine
+
+	// interactive reports whether stdin is attached to a terminal.
+	interactive bool
+
+	// color reports whether output may use ANSI colors.
+	color bool
+
+	// rng drives every random outcome, such as catch rolls. Tests inject a
+	// seeded source for deterministic results.
+	rng *rand.Rand
+
+	// catchModel selects the catch formula: base experience or species capture rate.
+	catchModel string
+
+	// savePath is the file the session is persisted to; empty disables persistence.
+	savePath string
+
+	// starter is the name of the starter Pokemon chosen with the starter command.
+	starter string
+
+	// seen holds every Pokemon name surfaced while exploring areas, such as
+	// with explore or nearby. Caught Pokemon are not added; completion
+	// counts them as seen.
+	seen map[string]struct{}
+
+	// explored lists the location areas explored this session, in order.
+	explored []string
+
+	// dumpPath is where a JSON summary of the cache is written on exit, if set.
+	dumpPath string
+
+	// theme holds the catch messages.
+	theme theme
+
+	// quiet suppresses prompts, notices, and progress messages, leaving only
+	// the requested data and errors. Confirmations default to no.
+	quiet bool
+
+	// pageSize is the number of location areas on a map page; zero means
+	// pokeapi.DefaultPageSize.
+	pageSize int
+
+	// listLimit caps how many entries long listings show unless --all is
+	// given; zero shows everything.
+	listLimit int
+
+	// mapPage lists the areas on the most recently displayed map page.
+	mapPage []string
+
+	// lastList holds the most recently displayed list of names (areas from
+	// map/mapb, Pokemon from explore) so they can be referenced by index.
+	lastList []string
 }
 
 // cliCommand represents a command that can be executed in the Pokedex REPL.
@@ -25,56 +101,192 @@ type cliCommand struct {
 	name        string
 	description string
 	callback    func(*config, []string) error
+
+	// hidden omits the command from help unless "help --all" is used; it
+	// marks advanced commands.
+	hidden bool
+
+	// preserveCase passes arguments to the callback without lowercasing them,
+	// for commands that accept file paths.
+	preserveCase bool
 }
 
 func main() {
+	offline := flag.Bool("offline", false, "serve responses only from the cache, never the network")
+	baseURL := flag.String("base-url", pokeapi.BaseURL, "PokeAPI base URL, for using a mirror")
+	strictJSON := flag.Bool("strict-json", false, "fail on response fields the API types do not declare")
+	colorMode := flag.String("color", colorAuto, "when to use colors: auto, always, or never")
+	noColor := flag.Bool("no-color", false, "disable colors (same as -color=never)")
+	savePath := flag.String("save", "", "file to persist the Pokedex to between runs")
+	dumpPath := flag.String("dump-cache-on-exit", "", "file to write a JSON summary of the cache to on exit")
+	themePath := flag.String("theme", "", "JSON file with custom catch messages")
+	quiet := flag.Bool("quiet", false, "print only requested data and errors; confirmations default to no")
+	pageSize := flag.Int("page-size", pokeapi.DefaultPageSize, "number of location areas on a map page")
+	listLimit := flag.Int("list-limit", defaultListLimit, "entries to show in long move and encounter listings (0 shows all)")
+	catchModel := flag.String("catch-model", catchModelBaseExp, "catch formula: base-exp or species (uses the species capture rate)")
+	flag.Parse()
+
+	if err := validateCatchModel(*catchModel); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *pageSize < 1 {
+		fmt.Fprintf(os.Stderr, "page size must be at least 1, got %d\n", *pageSize)
+		os.Exit(2)
+	}
+
+	if *noColor {
+		*colorMode = colorNever
+	}
+	_, noColorEnv := os.LookupEnv("NO_COLOR")
+	useColor, err := colorEnabled(*colorMode, noColorEnv, isInteractive(os.Stdout))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
 	// Initialize application state
-	client := pokeapi.NewClient()
-	firstURL := client.GetFirstLocationAreasURL()
+	opts := []pokeapi.Option{
+		pokeapi.WithBaseURL(*baseURL),
+		pokeapi.WithNotices(noticesFor(*quiet, os.Stdout)),
+	}
+	if *offline {
+		opts = append(opts, pokeapi.WithOffline())
+	}
+	if *strictJSON {
+		opts = append(opts, pokeapi.WithStrictJSON())
+	}
+	client := pokeapi.NewClient(opts...)
+	firstURL := client.LocationAreasURL(0, *pageSize)
+
+	scanner := bufio.NewScanner(os.Stdin)
 
 	cfg := &config{
-		client:  client,
-		nextURL: &firstURL,
-		prevURL: nil,
-		pokedex: make(map[string]pokeapi.Pokemon),
+		client:      client,
+		nextURL:     &firstURL,
+		prevURL:     nil,
+		pokedex:     make(map[string]pokeapi.Pokemon),
+		seen:        make(map[string]struct{}),
+		in:          scanner,
+		interactive: isInteractive(os.Stdin),
+		color:       useColor,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		savePath:    *savePath,
+		catchModel:  *catchModel,
+		listLimit:   *listLimit,
+		pageSize:    *pageSize,
+		quiet:       *quiet,
+		dumpPath:    *dumpPath,
+	}
+
+	if *themePath != "" {
+		t, err := loadTheme(*themePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v; using the default theme\n", err)
+		}
+		cfg.theme = t
 	}
 
+	if cfg.savePath != "" {
+		if err := loadSave(cfg.savePath, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Signals are handled by the REPL loop so that the exit cleanup runs on
+	// this goroutine, never concurrently with a command.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
 	// Start the REPL
-	scanner := bufio.NewScanner(os.Stdin)
+	if sig := repl(cfg, signals); sig != nil {
+		fmt.Println()
+		cfg.shutdown()
+		os.Exit(1)
+	}
+	cfg.shutdown()
+}
 
+// repl reads and runs commands until the input ends or a signal asks the
+// program to stop, and returns that signal, if any. Ctrl-C at the prompt
+// stops the REPL. During a command, any signal cancels cfg.ctx, which
+// abandons the command's requests and lets interruptible commands stop
+// early; after Ctrl-C the REPL keeps going, while other signals stop it once
+// the command returns.
+func repl(cfg *config, signals <-chan os.Signal) os.Signal {
 	for {
-		fmt.Print("Pokedex > ")
+		ctx, stop := signalContext(signals)
+		cfg.ctx = ctx
 
-		if !scanner.Scan() {
-			break
+		if !cfg.quiet {
+			fmt.Print("Pokedex > ")
 		}
 
-		input := scanner.Text()
-		args := cleanInput(input)
-
-		if len(args) == 0 {
-			continue
+		line, ok := cfg.readLine()
+		if ok {
+			runCommand(cfg, line)
 		}
-
-		cmdName := args[0]
-		cmd, exists := getCommands()[cmdName]
-		if !exists {
-			fmt.Println("Unknown command. Type 'help' for available commands.")
-			continue
+		sig := stop()
+		if !ok || (sig != nil && sig != os.Interrupt) {
+			return sig
 		}
+	}
+}
 
-		if err := cmd.callback(cfg, args[1:]); err != nil {
-			fmt.Printf("Error: %v\n", err)
+// shutdown runs the exit cleanup: it saves the session and, if requested,
+// dumps the cache. Failures are reported as warnings.
+func (cfg *config) shutdown() {
+	cfg.persist()
+	if cfg.dumpPath != "" {
+		if err := writeCacheDump(cfg.dumpPath, cfg.client.Cache()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 		}
 	}
 }
 
+// runCommand parses a line of input and executes the matching command,
+// reporting any error it returns.
+func runCommand(cfg *config, input string) {
+	args := cleanInput(input)
+
+	if len(args) == 0 {
+		return
+	}
+
+	cmdName := args[0]
+	cmd, exists := getCommands()[cmdName]
+	if !exists {
+		fmt.Println("Unknown command. Type 'help' for available commands.")
+		return
+	}
+
+	cmdArgs := args[1:]
+	if cmd.preserveCase {
+		cmdArgs = strings.Fields(input)[1:]
+	}
+
+	// --fresh works with any command: it swaps in a client that skips cache
+	// reads for the duration of this command.
+	if i := slices.Index(cmdArgs, "--fresh"); i >= 0 {
+		cmdArgs = slices.Delete(slices.Clone(cmdArgs), i, i+1)
+		client := cfg.client
+		cfg.client = client.Fresh()
+		defer func() { cfg.client = client }()
+	}
+
+	if err := cmd.callback(cfg, cmdArgs); err != nil {
+		fmt.Printf("%s %v\n", cfg.colorize(ansiRed, "Error:"), err)
+	}
+}
+
 // getCommands returns all available CLI commands.
 func getCommands() map[string]cliCommand {
 	return map[string]cliCommand{
 		"help": {
 			name:        "help",
-			description: "Displays a help message",
+			description: "Displays a help message (usage: help [--all])",
 			callback:    commandHelp,
 		},
 		"exit": {
@@ -84,33 +296,118 @@ func getCommands() map[string]cliCommand {
 		},
 		"map": {
 			name:        "map",
-			description: "Lists the next 20 Pokemon locations",
+			description: "Lists the next page of Pokemon locations (usage: map [--page N])",
 			callback:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
-			description: "Lists the previous 20 Pokemon locations",
+			description: "Lists the previous page of Pokemon locations",
 			callback:    commandMapb,
 		},
 		"explore": {
 			name:        "explore",
-			description: "Shows all Pokemon in a location (usage: explore <location-name>)",
+			description: "Shows all Pokemon in a location (usage: explore <location-name> [--details] [--api-order] [--min-level N] [--max-level N] [--all])",
 			callback:    commandExplore,
 		},
+		"explored": {
+			name:        "explored",
+			description: "Lists the areas you have explored this session",
+			callback:    commandExplored,
+		},
+		"seen": {
+			name:        "seen",
+			description: "Lists every Pokemon you have encountered while exploring",
+			callback:    commandSeen,
+		},
+		"completion": {
+			name:        "completion",
+			description: "Shows how many Pokemon you have seen and caught",
+			callback:    commandCompletion,
+		},
+		"nearby": {
+			name:        "nearby",
+			description: "Shows uncaught Pokemon in an area, or ranks the current map page's areas (usage: nearby [area])",
+			callback:    commandNearby,
+		},
+		"region": {
+			name:        "region",
+			description: "Ranks the most common Pokemon across the first N areas (usage: region <limit> [--top=N])",
+			callback:    commandRegion,
+		},
 		"catch": {
 			name:        "catch",
-			description: "Attempt to catch a Pokemon (usage: catch <pokemon-name>)",
+			description: "Attempt to catch a Pokemon (usage: catch <pokemon-name> [--times N])",
 			callback:    commandCatch,
 		},
+		"catchtype": {
+			name:        "catchtype",
+			description: "Tries to catch every Pokemon of a type in an area (usage: catchtype <area> <type>)",
+			callback:    commandCatchType,
+		},
+		"importtargets": {
+			name:         "importtargets",
+			description:  "Tries to catch every Pokemon listed in a file, one name per line (usage: importtargets <file>)",
+			callback:     commandImportTargets,
+			preserveCase: true,
+		},
 		"inspect": {
 			name:        "inspect",
-			description: "View details of a caught Pokemon (usage: inspect <pokemon-name>)",
+			description: "View details of a caught Pokemon (usage: inspect <pokemon-name> [--strict] [--vs-average] [--fields=a,b,...])",
 			callback:    commandInspect,
 		},
 		"pokedex": {
-			name:        "pokedex",
-			description: "Lists all Pokemon you have caught",
-			callback:    commandPokedex,
+			name:         "pokedex",
+			description:  "Lists all Pokemon you have caught (usage: pokedex [--export <file>])",
+			callback:     commandPokedex,
+			preserveCase: true,
+		},
+		"moves": {
+			name:        "moves",
+			description: "Lists the moves a Pokemon can learn (usage: moves <pokemon-name> [--by-class] [--all])",
+			callback:    commandMoves,
+		},
+		"search": {
+			name:        "search",
+			description: "Find Pokemon whose name contains a substring (usage: search <substring> [--sort=alpha|length] [--limit=N])",
+			callback:    commandSearch,
+		},
+		"cry": {
+			name:        "cry",
+			description: "Show or open a Pokemon's cry audio (usage: cry <pokemon-name> [--url|--open])",
+			callback:    commandCry,
+		},
+		"starter": {
+			name:        "starter",
+			description: "Choose a starter Pokemon from three random picks",
+			callback:    commandStarter,
+		},
+		"watch": {
+			name:         "watch",
+			description:  "Re-runs a command periodically until Ctrl-C (usage: watch <interval> <command...>)",
+			callback:     commandWatch,
+			preserveCase: true,
+		},
+		"clearpokedex": {
+			name:        "clearpokedex",
+			description: "Release every Pokemon you have caught (usage: clearpokedex [-y])",
+			callback:    commandClearPokedex,
+		},
+		"stats": {
+			name:        "stats",
+			description: "Shows a histogram of a base stat across your Pokedex (usage: stats --distribution <stat>)",
+			callback:    commandStats,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Inspect the response cache (usage: cache size|stats|latency|keys [prefix]|clear <prefix>)",
+			callback:    commandCache,
+			hidden:      true,
+		},
+		"benchmark": {
+			name:        "benchmark",
+			description: "Times repeated explores of an area to measure the cache (usage: benchmark <area> <iterations>)",
+			callback:    commandBenchmark,
+			hidden:      true,
 		},
 	}
 }
@@ -125,157 +422,593 @@ func cleanInput(text string) []string {
 	return result
 }
 
+// isInteractive reports whether f is a terminal rather than a pipe or file.
+func isInteractive(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks a yes/no question on stderr and reads the answer from the
+// REPL input. Anything other than "y" or "yes" is treated as no. In quiet
+// mode nothing is asked and the answer is no.
+func confirm(cfg *config, prompt string) bool {
+	if cfg.quiet {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", prompt)
+	line, ok := cfg.readLine()
+	if !ok {
+		fmt.Fprintln(os.Stderr)
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
+// noticesFor returns where client notices, such as cache hits, are written:
+// w normally, and nowhere in quiet mode.
+func noticesFor(quiet bool, w io.Writer) io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	return w
+}
+
+// progressf prints a progress or hint message unless quiet mode is on.
+func (cfg *config) progressf(format string, args ...any) {
+	if !cfg.quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// markExplored appends area to explored unless it is already listed.
+func markExplored(explored []string, area string) []string {
+	if area == "" || slices.Contains(explored, area) {
+		return explored
+	}
+	return append(explored, area)
+}
+
+// limitFor returns the listing cap for a command, or zero when --all was given.
+func (cfg *config) limitFor(opts commandArgs) int {
+	if opts.has("--all") {
+		return 0
+	}
+	return cfg.listLimit
+}
+
+// resolveIndex interprets arg as a 1-based index into list and returns the
+// matching name. Non-numeric or out-of-range arguments are returned unchanged
+// so they can be treated as names.
+func resolveIndex(list []string, arg string) string {
+	i, err := strconv.Atoi(arg)
+	if err != nil || i < 1 || i > len(list) {
+		return arg
+	}
+	return list[i-1]
+}
+
 // commandHelp displays all available commands and their descriptions.
+// Hidden commands are only listed with --all.
 func commandHelp(cfg *config, args []string) error {
-	fmt.Println()
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("Usage:")
-	fmt.Println()
-	for name, cmd := range getCommands() {
-		fmt.Printf("  %s: %s\n", name, cmd.description)
-	}
-	fmt.Println()
+	writeHelp(os.Stdout, getCommands(), parseArgs(args).has("--all"))
 	return nil
 }
 
+// writeHelp writes the help message for commands to w in alphabetical order,
+// including hidden commands only when all is set.
+func writeHelp(w io.Writer, commands map[string]cliCommand, all bool) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Welcome to the Pokedex!")
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w)
+	for _, name := range slices.Sorted(maps.Keys(commands)) {
+		cmd := commands[name]
+		if cmd.hidden && !all {
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %s\n", name, cmd.description)
+	}
+	if !all {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Use 'help --all' to include advanced commands.")
+	}
+	fmt.Fprintln(w)
+}
+
 // commandExit terminates the Pokedex application.
 func commandExit(cfg *config, args []string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
+	cfg.shutdown()
 	os.Exit(0)
 	return nil
 }
 
-// commandMap displays the next 20 Pokemon location areas.
+// commandMap displays the next page of Pokemon location areas.
 func commandMap(cfg *config, args []string) error {
+	opts := parseArgs(args, "--page")
+	if _, ok := opts.value("--page"); ok {
+		page, err := opts.intValue("--page", 1)
+		if err != nil {
+			return err
+		}
+		return showMapPage(cfg, page)
+	}
+
 	if cfg.nextURL == nil {
 		fmt.Println("You're on the last page")
 		return nil
 	}
 
-	resp, err := cfg.client.GetLocationAreas(*cfg.nextURL)
+	resp, err := cfg.client.GetLocationAreasContext(cfg.commandContext(), *cfg.nextURL)
 	if err != nil {
 		return err
 	}
 
-	// Update pagination state
-	cfg.nextURL = resp.Next
-	cfg.prevURL = resp.Previous
-
-	// Display locations
-	for _, loc := range resp.Results {
-		fmt.Println(loc.Name)
-	}
-
+	showLocationAreas(cfg, resp)
 	return nil
 }
 
-// commandMapb displays the previous 20 Pokemon location areas.
+// commandMapb displays the previous page of Pokemon location areas.
 func commandMapb(cfg *config, args []string) error {
 	if cfg.prevURL == nil {
 		fmt.Println("You're on the first page")
 		return nil
 	}
 
-	resp, err := cfg.client.GetLocationAreas(*cfg.prevURL)
+	resp, err := cfg.client.GetLocationAreasContext(cfg.commandContext(), *cfg.prevURL)
+	if err != nil {
+		return err
+	}
+
+	showLocationAreas(cfg, resp)
+	return nil
+}
+
+// showMapPage jumps to a 1-based page of location areas. Pages past the end
+// are reported with the valid range and leave the pagination state unchanged.
+func showMapPage(cfg *config, page int) error {
+	if err := validatePage(page, 0); err != nil {
+		return err
+	}
+
+	size := cmp.Or(cfg.pageSize, pokeapi.DefaultPageSize)
+	resp, err := cfg.client.GetLocationAreasContext(cfg.commandContext(), cfg.client.LocationAreasURL(pageOffset(page, size), size))
 	if err != nil {
 		return err
 	}
+	if err := validatePage(page, pageCount(resp.Count, size)); err != nil {
+		return err
+	}
+	if len(resp.Results) == 0 {
+		return fmt.Errorf("there are no location areas")
+	}
 
+	showLocationAreas(cfg, resp)
+	return nil
+}
+
+// showLocationAreas updates pagination state from a page of location areas and
+// prints them, marking areas already explored this session.
+func showLocationAreas(cfg *config, resp *pokeapi.LocationAreasResponse) {
 	// Update pagination state
 	cfg.nextURL = resp.Next
 	cfg.prevURL = resp.Previous
+	cfg.lastList = cfg.lastList[:0]
 
 	// Display locations
 	for _, loc := range resp.Results {
-		fmt.Println(loc.Name)
+		if slices.Contains(cfg.explored, loc.Name) {
+			fmt.Printf("%s ✓\n", loc.Name)
+		} else {
+			fmt.Println(loc.Name)
+		}
+		cfg.lastList = append(cfg.lastList, loc.Name)
 	}
-
-	return nil
+	cfg.mapPage = slices.Clone(cfg.lastList)
 }
 
 // commandExplore displays all Pokemon that can be encountered in a given location.
 func commandExplore(cfg *config, args []string) error {
-	if len(args) == 0 {
+	opts := parseArgs(args, "--min-level", "--max-level")
+	if len(opts.positional) == 0 {
 		return fmt.Errorf("please provide a location name (e.g., 'explore canalave-city-area')")
 	}
 
-	locationName := args[0]
+	var levels levelRange
+	var err error
+	if levels.min, err = opts.intValue("--min-level", 0); err != nil {
+		return err
+	}
+	if levels.max, err = opts.intValue("--max-level", 0); err != nil {
+		return err
+	}
+	if err := levels.validate(); err != nil {
+		return err
+	}
+
+	locationName := resolveIndex(cfg.lastList, opts.positional[0])
 
-	resp, err := cfg.client.GetLocationArea(locationName)
+	resp, err := cfg.client.GetLocationAreaContext(cfg.commandContext(), locationName)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Exploring %s...\n", resp.Location.Name)
-	fmt.Println("Found Pokemon:")
+	cfg.explored = markExplored(cfg.explored, resp.Name)
+
+	names := writeExplore(os.Stdout, resp, exploreOptions{
+		apiOrder: opts.has("--api-order"),
+		details:  opts.has("--details"),
+		levels:   levels,
+		limit:    cfg.limitFor(opts),
+	})
+	// An area without matches still replaces the list, so that indexes
+	// never refer to an older listing.
+	cfg.lastList = names
+
+	// Every Pokemon in the area counts as seen, including ones hidden by
+	// truncation or the level filter. Only save when that adds new ones.
+	seenBefore := len(cfg.seen)
+	cfg.seen = recordSeen(cfg.seen, encounterNames(resp)...)
+	if len(cfg.seen) > seenBefore {
+		cfg.persist()
+	}
+
+	return nil
+}
+
+// commandExplored lists the areas explored this session in the order they were visited.
+func commandExplored(cfg *config, args []string) error {
+	if len(cfg.explored) == 0 {
+		fmt.Println("You haven't explored any areas yet.")
+		return nil
+	}
+
+	fmt.Println("Explored areas:")
+	for i, name := range cfg.explored {
+		fmt.Printf("  %d. %s\n", i+1, name)
+	}
+
+	return nil
+}
 
-	if len(resp.PokemonEncounters) == 0 {
-		fmt.Println("  No Pokemon found in this area.")
-	} else {
-		for _, encounter := range resp.PokemonEncounters {
-			fmt.Printf("  - %s\n", encounter.Pokemon.Name)
+// commandSeen lists every Pokemon encountered so far, marking caught ones.
+func commandSeen(cfg *config, args []string) error {
+	if len(cfg.seen) == 0 {
+		fmt.Println("You haven't seen any Pokemon yet. Try exploring an area!")
+		return nil
+	}
+
+	fmt.Println("Seen Pokemon:")
+	for _, name := range sortedSeen(cfg.seen) {
+		if _, caught := cfg.pokedex[name]; caught {
+			fmt.Printf("  - %s (caught)\n", name)
+		} else {
+			fmt.Printf("  - %s\n", name)
 		}
 	}
 
 	return nil
 }
 
+// commandCompletion summarizes the Pokemon seen and caught against the National Pokedex.
+func commandCompletion(cfg *config, args []string) error {
+	seen, caught := completion(cfg.seen, cfg.pokedex)
+	fmt.Printf("Seen: %d/%d (%s)\n", seen, maxDexID, percent(seen, maxDexID))
+	fmt.Printf("Caught: %d/%d (%s)\n", caught, maxDexID, percent(caught, maxDexID))
+	return nil
+}
+
+// commandRegion explores the first limit location areas and ranks the Pokemon
+// that appear in the most of them.
+func commandRegion(cfg *config, args []string) error {
+	opts := parseArgs(args, "--top")
+	if len(opts.positional) == 0 {
+		return fmt.Errorf("please provide the number of areas to scan (e.g., 'region 20')")
+	}
+
+	limit, err := strconv.Atoi(opts.positional[0])
+	if err != nil || limit < 1 {
+		return fmt.Errorf("the number of areas must be a positive number, got %q", opts.positional[0])
+	}
+
+	top, err := opts.intValue("--top", 10)
+	if err != nil {
+		return err
+	}
+
+	list, err := cfg.client.GetLocationAreasContext(cfg.commandContext(), cfg.client.LocationAreasURL(0, limit))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(list.Results))
+	for i, area := range list.Results {
+		names[i] = area.Name
+	}
+
+	ctx, stop := cfg.interruptible()
+	defer stop()
+
+	cfg.progressf("Scanning %d areas...\n", len(names))
+	areas, err := cfg.client.GetLocationAreaBatchContext(ctx, names)
+	switch {
+	case errors.Is(err, context.Canceled):
+		fmt.Printf("Interrupted; showing results for %d of %d areas.\n", countLoaded(areas), len(names))
+	case err != nil:
+		fmt.Printf("Some areas could not be explored: %v\n", err)
+	}
+
+	ranked := rankByFrequency(areas)
+	if len(ranked) == 0 {
+		fmt.Println("No Pokemon found.")
+		return nil
+	}
+
+	if top > 0 && len(ranked) > top {
+		ranked = ranked[:top]
+	}
+	for i, p := range ranked {
+		fmt.Printf("  %d. %s (%d of %d areas)\n", i+1, p.name, p.areas, len(names))
+	}
+
+	return nil
+}
+
+// commandNearby reports how many Pokemon in an area are still uncaught. Without
+// an area it scans every area on the current map page and ranks them.
+func commandNearby(cfg *config, args []string) error {
+	if len(args) > 0 {
+		area, err := cfg.client.GetLocationAreaContext(cfg.commandContext(), resolveIndex(cfg.lastList, args[0]))
+		if err != nil {
+			return err
+		}
+		cfg.seen = recordSeen(cfg.seen, encounterNames(area)...)
+
+		progress := progressIn(area, cfg.pokedex)
+		switch {
+		case progress.total == 0:
+			fmt.Printf("No Pokemon can be found in %s.\n", progress.name)
+		case len(progress.uncaught) == 0:
+			fmt.Printf("You have caught all %d Pokemon in %s. Try another area.\n", progress.total, progress.name)
+		default:
+			fmt.Printf("%d of %d Pokemon in %s are uncaught: %s\n", len(progress.uncaught), progress.total,
+				progress.name, strings.Join(progress.uncaught, ", "))
+			fmt.Println("Worth exploring!")
+		}
+		return nil
+	}
+
+	if len(cfg.mapPage) == 0 {
+		return fmt.Errorf("please provide an area, or use 'map' first to scan its areas (e.g., 'nearby canalave-city-area')")
+	}
+
+	ctx, stop := cfg.interruptible()
+	defer stop()
+
+	cfg.progressf("Scanning %d areas...\n", len(cfg.mapPage))
+	areas, err := cfg.client.GetLocationAreaBatchContext(ctx, cfg.mapPage)
+	switch {
+	case errors.Is(err, context.Canceled):
+		fmt.Printf("Interrupted; ranking %d of %d areas.\n", countLoaded(areas), len(cfg.mapPage))
+	case err != nil:
+		fmt.Printf("Some areas could not be explored: %v\n", err)
+	}
+
+	for i, progress := range rankByUncaught(areas, cfg.pokedex) {
+		fmt.Printf("  %d. %s (%d of %d uncaught)\n", i+1, progress.name, len(progress.uncaught), progress.total)
+	}
+	return nil
+}
+
 // commandCatch attempts to catch a Pokemon and add it to the user's Pokedex.
+// With --times N it retries up to N times, stopping at the first catch.
 func commandCatch(cfg *config, args []string) error {
-	if len(args) == 0 {
+	opts := parseArgs(args, "--times")
+	if len(opts.positional) == 0 {
 		return fmt.Errorf("please provide a Pokemon name (e.g., 'catch pikachu')")
 	}
 
-	pokemonName := args[0]
+	times, err := opts.intValue("--times", 1)
+	if err != nil {
+		return err
+	}
+	if times < 1 {
+		return fmt.Errorf("--times must be at least 1")
+	}
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+	pokemonName := resolveIndex(cfg.lastList, opts.positional[0])
+
+	if _, caught := cfg.pokedex[pokemonName]; caught {
+		if !cfg.interactive {
+			fmt.Printf("You already caught %s. Skipping.\n", pokemonName)
+			return nil
+		}
+		if !confirm(cfg, fmt.Sprintf("You already caught %s. Catch it again?", pokemonName)) {
+			return nil
+		}
+	}
 
 	// Fetch Pokemon data
-	pokemon, err := cfg.client.GetPokemon(pokemonName)
+	pokemon, err := cfg.client.GetPokemonContext(cfg.commandContext(), pokemonName)
 	if err != nil {
 		return err
 	}
 
-	// Calculate catch chance based on base experience
-	// Higher base experience = harder to catch
-	// Base experience ranges from ~36 (low) to ~608 (legendary)
-	// We'll use a threshold approach: random number must exceed a scaled value
-	const maxBaseExp = 400
-	catchThreshold := pokemon.BaseExperience
-	catchThreshold = min(catchThreshold, maxBaseExp)
+	roll := cfg.catchRollFor(pokemon)
 
-	// Generate random number between 0 and maxBaseExp
-	// If random >= catchThreshold, the Pokemon is caught
-	roll := rand.Intn(maxBaseExp)
+	for attempt := 1; attempt <= times; attempt++ {
+		if times > 1 {
+			cfg.progressf("[%d/%d] ", attempt, times)
+		}
+		cfg.progressf("%s\n", cfg.theme.throw(pokemonName))
 
-	if roll >= catchThreshold {
-		fmt.Printf("%s was caught!\n", pokemonName)
-		fmt.Println("You may now inspect it with the inspect command.")
-		cfg.pokedex[pokemonName] = *pokemon
-	} else {
-		fmt.Printf("%s escaped!\n", pokemonName)
+		if roll() {
+			fmt.Println(cfg.colorize(ansiGreen, cfg.theme.caught(pokemonName)))
+			cfg.progressf("You may now inspect it with the inspect command.\n")
+			cfg.pokedex[pokemonName] = *pokemon
+			cfg.persist()
+			return nil
+		}
+		fmt.Println(cfg.colorize(ansiRed, cfg.theme.escaped(pokemonName)))
 	}
 
 	return nil
 }
 
+// commandCatchType explores an area and tries once to catch every Pokemon
+// there with the given type. Pokemon that fail to load are reported and skipped.
+func commandCatchType(cfg *config, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("please provide an area and a type (e.g., 'catchtype canalave-city-area water')")
+	}
+
+	area, err := cfg.client.GetLocationAreaContext(cfg.commandContext(), resolveIndex(cfg.lastList, args[0]))
+	if err != nil {
+		return err
+	}
+	typeName := args[1]
+
+	names := encounterNames(area)
+	cfg.seen = recordSeen(cfg.seen, names...)
+
+	ctx, stop := cfg.interruptible()
+	defer stop()
+
+	pokemon, err := cfg.client.GetPokemonBatchContext(ctx, names)
+	switch {
+	case errors.Is(err, context.Canceled):
+		fmt.Printf("Interrupted after loading %d of %d Pokemon; nothing was caught.\n", countLoaded(pokemon), len(names))
+		writeCatchSummary(os.Stdout, catchSummary{})
+		return nil
+	case err != nil:
+		fmt.Printf("Some Pokemon could not be loaded: %v\n", err)
+	}
+
+	matches := filterByType(pokemon, typeName)
+	if len(matches) == 0 {
+		fmt.Printf("No %s-type Pokemon found in %s.\n", typeName, area.Name)
+		return nil
+	}
+
+	var summary catchSummary
+	for i, p := range matches {
+		if ctx.Err() != nil {
+			fmt.Printf("Interrupted; %d Pokemon were not attempted.\n", len(matches)-i)
+			break
+		}
+		if _, caught := cfg.pokedex[p.Name]; caught {
+			summary.skipped = append(summary.skipped, p.Name)
+			continue
+		}
+
+		cfg.progressf("%s\n", cfg.theme.throw(p.Name))
+		if cfg.catchRollFor(p)() {
+			fmt.Println(cfg.colorize(ansiGreen, cfg.theme.caught(p.Name)))
+			cfg.pokedex[p.Name] = *p
+			summary.caught = append(summary.caught, p.Name)
+		} else {
+			fmt.Println(cfg.colorize(ansiRed, cfg.theme.escaped(p.Name)))
+			summary.escaped = append(summary.escaped, p.Name)
+		}
+	}
+	cfg.persist()
+
+	writeCatchSummary(os.Stdout, summary)
+	return nil
+}
+
+// commandImportTargets tries once to catch each Pokemon listed in a file.
+// Pokemon that are already caught are skipped, and ones that fail to load are
+// reported without stopping the import. Ctrl-C stops the import and prints
+// the summary so far.
+func commandImportTargets(cfg *config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide a file of Pokemon names (e.g., 'importtargets targets.txt')")
+	}
+
+	names, err := loadTargets(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := cfg.interruptible()
+	defer stop()
+
+	var summary catchSummary
+	for i, name := range names {
+		if ctx.Err() != nil {
+			fmt.Printf("Interrupted; %d targets were not attempted.\n", len(names)-i)
+			break
+		}
+		if _, caught := cfg.pokedex[name]; caught {
+			fmt.Printf("%s: already caught\n", name)
+			summary.skipped = append(summary.skipped, name)
+			continue
+		}
+
+		pokemon, err := cfg.client.GetPokemonContext(ctx, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Printf("Interrupted; %d targets were not attempted.\n", len(names)-i)
+				break
+			}
+			fmt.Printf("%s: %v\n", name, err)
+			summary.failed = append(summary.failed, name)
+			continue
+		}
+
+		if cfg.catchRollFor(pokemon)() {
+			fmt.Printf("%s: %s\n", name, cfg.colorize(ansiGreen, "caught"))
+			cfg.pokedex[name] = *pokemon
+			summary.caught = append(summary.caught, name)
+		} else {
+			fmt.Printf("%s: %s\n", name, cfg.colorize(ansiRed, "escaped"))
+			summary.escaped = append(summary.escaped, name)
+		}
+	}
+	cfg.persist()
+
+	writeCatchSummary(os.Stdout, summary)
+	return nil
+}
+
 // commandInspect displays details of a caught Pokemon from the user's Pokedex.
 func commandInspect(cfg *config, args []string) error {
-	if len(args) == 0 {
+	opts := parseArgs(args, "--fields")
+	if len(opts.positional) == 0 {
 		return fmt.Errorf("please provide a Pokemon name (e.g., 'inspect pikachu')")
 	}
 
-	pokemonName := args[0]
+	pokemonName := opts.positional[0]
 
 	pokemon, ok := cfg.pokedex[pokemonName]
 	if !ok {
+		if opts.has("--strict") {
+			return fmt.Errorf("you have not caught %s", pokemonName)
+		}
 		fmt.Println("you have not caught that pokemon")
 		return nil
 	}
 
+	if fields, ok := opts.value("--fields"); ok {
+		lines, err := selectFields(&pokemon, strings.Split(fields, ","))
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return nil
+	}
+
 	fmt.Printf("Name: %s\n", pokemon.Name)
 	fmt.Printf("Height: %d\n", pokemon.Height)
 	fmt.Printf("Weight: %d\n", pokemon.Weight)
+	fmt.Printf("Nature: %s\n", deriveNature(pokemon.Name))
 	fmt.Println("Stats:")
 	for _, stat := range pokemon.Stats {
 		fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
@@ -285,11 +1018,31 @@ func commandInspect(cfg *config, args []string) error {
 		fmt.Printf("  - %s\n", t.Type.Name)
 	}
 
+	if opts.has("--vs-average") {
+		fmt.Println("Compared to average:")
+		for _, d := range compareToAverage(pokemon.Stats) {
+			fmt.Printf("  -%s: %d vs %d (%+d)\n", d.name, d.value, d.average, d.delta)
+		}
+	}
+
 	return nil
 }
 
 // commandPokedex lists all Pokemon the user has caught.
 func commandPokedex(cfg *config, args []string) error {
+	opts := parseArgs(args, "--export")
+	if path, ok := opts.value("--export"); ok {
+		if path == "" {
+			return fmt.Errorf("please provide a file to export to (e.g., 'pokedex --export pokedex.json')")
+		}
+		count, err := exportPokedex(path, cfg.pokedex)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d Pokemon to %s\n", count, path)
+		return nil
+	}
+
 	if len(cfg.pokedex) == 0 {
 		fmt.Println("Your Pokedex is empty. Try catching some Pokemon!")
 		return nil
@@ -302,3 +1055,370 @@ func commandPokedex(cfg *config, args []string) error {
 
 	return nil
 }
+
+// commandStats reports statistics about the caught Pokemon.
+func commandStats(cfg *config, args []string) error {
+	opts := parseArgs(args, "--distribution")
+	stat, ok := opts.value("--distribution")
+	if !ok || stat == "" {
+		return fmt.Errorf("please choose a stat (e.g., 'stats --distribution hp')")
+	}
+
+	if len(cfg.pokedex) == 0 {
+		fmt.Println("Your Pokedex is empty. Try catching some Pokemon!")
+		return nil
+	}
+
+	values := make([]int, 0, len(cfg.pokedex))
+	for _, pokemon := range cfg.pokedex {
+		if value, ok := statValue(&pokemon, stat); ok {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("none of your Pokemon have a %q stat (e.g., hp, attack, defense, special-attack, special-defense, speed)", stat)
+	}
+
+	writeHistogram(os.Stdout, stat, len(values), bucketize(values, bucketWidth))
+	return nil
+}
+
+// commandCache reports information about the client's response cache.
+func commandCache(cfg *config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide a subcommand (e.g., 'cache size', 'cache stats', or 'cache clear pokemon')")
+	}
+
+	switch args[0] {
+	case "size":
+		c := cfg.client.Cache()
+		fmt.Printf("Cache entries: %d\n", c.Len())
+		fmt.Printf("Cache size: %s\n", formatBytes(c.Bytes()))
+	case "stats":
+		stats := cfg.client.Stats()
+		fmt.Printf("Cache entries: %d\n", cfg.client.Cache().Len())
+		fmt.Printf("Hits: %d\n", stats.Hits)
+		fmt.Printf("Misses: %d\n", stats.Misses)
+		fmt.Printf("Coalesced: %d\n", stats.Coalesced)
+		if total := stats.Hits + stats.Misses + stats.Coalesced; total > 0 {
+			fmt.Printf("Hit ratio: %.1f%%\n", 100*float64(stats.Hits)/float64(total))
+		}
+	case "latency":
+		latencies := cfg.client.Latencies()
+		if len(latencies) == 0 {
+			fmt.Println("No requests have been sent to the API yet.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Endpoint\tCount\tp50\tp95\tmax")
+		for _, l := range latencies {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", l.Endpoint, l.Count,
+				l.P50.Round(time.Millisecond), l.P95.Round(time.Millisecond), l.Max.Round(time.Millisecond))
+		}
+		w.Flush()
+	case "keys":
+		prefix := ""
+		if len(args) > 1 {
+			prefix = cacheKeyPrefix(cfg, args[1])
+		}
+		for _, key := range cfg.client.Cache().KeysWithPrefix(prefix) {
+			fmt.Println(key)
+		}
+	case "clear":
+		if len(args) < 2 {
+			return fmt.Errorf("please provide an endpoint or URL prefix (e.g., 'cache clear pokemon')")
+		}
+		prefix := cacheKeyPrefix(cfg, args[1])
+		removed := cfg.client.Cache().DeletePrefix(prefix)
+		fmt.Printf("Removed %d cached responses under %s\n", removed, prefix)
+	default:
+		return fmt.Errorf("unknown cache subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+// commandBenchmark explores the same area repeatedly and compares the first
+// fetch with the cached ones.
+func commandBenchmark(cfg *config, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("please provide an area and an iteration count (e.g., 'benchmark canalave-city-area 10')")
+	}
+
+	iterations, err := strconv.Atoi(args[1])
+	if err != nil || iterations < 2 {
+		return fmt.Errorf("iterations must be a number of at least 2, got %q", args[1])
+	}
+
+	area := resolveIndex(cfg.lastList, args[0])
+	result, warm, err := benchmarkArea(cfg.commandContext(), cfg.client, area, iterations)
+	if err != nil {
+		return err
+	}
+
+	writeBenchmark(os.Stdout, area, result, warm)
+	return nil
+}
+
+// cacheKeyPrefix turns an endpoint name such as "pokemon" into the URL prefix
+// of its cache keys. Arguments that are already URLs are returned unchanged.
+func cacheKeyPrefix(cfg *config, arg string) string {
+	if strings.Contains(arg, "://") {
+		return arg
+	}
+	return cfg.client.EndpointURL(arg)
+}
+
+// formatBytes renders a byte count in a human-readable unit.
+func formatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// commandMoves lists the moves a Pokemon can learn, optionally grouped by damage class.
+func commandMoves(cfg *config, args []string) error {
+	opts := parseArgs(args)
+	if len(opts.positional) == 0 {
+		return fmt.Errorf("please provide a Pokemon name (e.g., 'moves pikachu')")
+	}
+
+	pokemon, err := cfg.client.GetPokemonContext(cfg.commandContext(), resolveIndex(cfg.lastList, opts.positional[0]))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(pokemon.Moves))
+	for i, m := range pokemon.Moves {
+		names[i] = m.Move.Name
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("%s has no known moves.\n", pokemon.Name)
+		return nil
+	}
+
+	limit := cfg.limitFor(opts)
+
+	if !opts.has("--by-class") {
+		fmt.Printf("Moves for %s:\n", pokemon.Name)
+		shown, hidden := truncateList(names, limit)
+		for _, name := range shown {
+			fmt.Printf("  - %s\n", name)
+		}
+		writeTruncationNotice(os.Stdout, hidden)
+		return nil
+	}
+
+	moves, err := cfg.client.GetMovesContext(cfg.commandContext(), names)
+	if err != nil {
+		fmt.Printf("Some moves could not be loaded: %v\n", err)
+	}
+
+	for _, group := range groupMovesByClass(moves) {
+		fmt.Printf("%s (%d):\n", strings.ToUpper(group.class[:1])+group.class[1:], len(group.moves))
+		shown, hidden := truncateList(group.moves, limit)
+		for _, name := range shown {
+			fmt.Printf("  - %s\n", name)
+		}
+		writeTruncationNotice(os.Stdout, hidden)
+	}
+
+	return nil
+}
+
+// commandSearch lists every Pokemon whose name contains the given substring,
+// with its National Pokedex number.
+func commandSearch(cfg *config, args []string) error {
+	opts := parseArgs(args, "--sort", "--limit")
+	if len(opts.positional) == 0 {
+		return fmt.Errorf("please provide part of a Pokemon name (e.g., 'search char')")
+	}
+
+	limit, err := opts.intValue("--limit", 0)
+	if err != nil {
+		return err
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	sortBy, _ := opts.value("--sort")
+
+	resp, err := cfg.client.GetPokemonListContext(cfg.commandContext())
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(resp.Results))
+	for i, p := range resp.Results {
+		names[i] = p.Name
+	}
+
+	matches, err := searchNames(names, opts.positional[0], sortBy)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No Pokemon found.")
+		return nil
+	}
+
+	shown := matches
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, name := range shown {
+		fmt.Printf("  - %s\n", formatSearchResult(name, cfg.client.ResolveName))
+	}
+	if omitted := len(matches) - len(shown); omitted > 0 {
+		fmt.Printf("... and %d more\n", omitted)
+	}
+
+	return nil
+}
+
+// commandCry prints the cry audio URLs of a Pokemon, or opens one with --open.
+func commandCry(cfg *config, args []string) error {
+	opts := parseArgs(args)
+	if len(opts.positional) == 0 {
+		return fmt.Errorf("please provide a Pokemon name (e.g., 'cry pikachu')")
+	}
+
+	pokemon, err := cfg.client.GetPokemonContext(cfg.commandContext(), resolveIndex(cfg.lastList, opts.positional[0]))
+	if err != nil {
+		return err
+	}
+
+	cries := pokemon.Cries
+
+	if opts.has("--open") {
+		url := cmp.Or(cries.Latest, cries.Legacy)
+		if url == "" {
+			return fmt.Errorf("%s has no cry available", pokemon.Name)
+		}
+		fmt.Printf("Opening %s...\n", url)
+		return openURL(url)
+	}
+
+	fmt.Printf("Latest: %s\n", cmp.Or(cries.Latest, "(none)"))
+	fmt.Printf("Legacy: %s\n", cmp.Or(cries.Legacy, "(none)"))
+
+	return nil
+}
+
+// commandWatch re-runs a command every interval, clearing the screen between
+// runs, until interrupted with Ctrl-C.
+func commandWatch(cfg *config, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("please provide an interval and a command (e.g., 'watch 5s cache size')")
+	}
+
+	interval, err := parseInterval(args[0])
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(args[1]) == "watch" {
+		return fmt.Errorf("cannot watch the watch command")
+	}
+	input := strings.Join(args[1:], " ")
+
+	// Ctrl-C while watching returns to the prompt.
+	ctx, stop := cfg.interruptible()
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fmt.Print(clearScreen)
+		fmt.Printf("Every %s: %s (Ctrl-C to stop)\n\n", interval, input)
+		runCommand(cfg, input)
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// commandClearPokedex releases every caught Pokemon after confirmation.
+func commandClearPokedex(cfg *config, args []string) error {
+	if len(cfg.pokedex) == 0 {
+		fmt.Println("Your Pokedex is already empty.")
+		return nil
+	}
+
+	if !parseArgs(args).has("-y") {
+		if !cfg.interactive {
+			fmt.Fprintln(os.Stderr, "Refusing to clear the Pokedex without confirmation; rerun with -y.")
+			return nil
+		}
+		if !confirm(cfg, fmt.Sprintf("Release all %d Pokemon?", len(cfg.pokedex))) {
+			fmt.Println("Your Pokedex was left untouched.")
+			return nil
+		}
+	}
+
+	count := len(cfg.pokedex)
+	clear(cfg.pokedex)
+	cfg.persist()
+	fmt.Printf("Released %d Pokemon. Your Pokedex is now empty.\n", count)
+
+	return nil
+}
+
+// commandStarter offers three random Pokemon and adds the chosen one to the
+// Pokedex without a catch roll.
+func commandStarter(cfg *config, args []string) error {
+	if cfg.starter != "" {
+		fmt.Printf("You already chose %s as your starter.\n", cfg.starter)
+		return nil
+	}
+
+	ids := pickStarterIDs(cfg.rng, starterChoices, maxDexID)
+	candidates, err := cfg.client.GetPokemonByIDsContext(cfg.commandContext(), ids)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Choose your starter:")
+	for i, pokemon := range candidates {
+		types := make([]string, len(pokemon.Types))
+		for j, t := range pokemon.Types {
+			types[j] = t.Type.Name
+		}
+		fmt.Printf("  %d. %s (%s) - stat total %d\n", i+1, pokemon.Name, strings.Join(types, "/"), statTotal(pokemon))
+	}
+
+	fmt.Fprintf(os.Stderr, "Pick a starter [1-%d]: ", len(candidates))
+	line, ok := cfg.readLine()
+	if !ok {
+		fmt.Fprintln(os.Stderr)
+		return nil
+	}
+	choice, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || choice < 1 || choice > len(candidates) {
+		fmt.Println("No starter chosen.")
+		return nil
+	}
+
+	chosen := candidates[choice-1]
+	cfg.pokedex[chosen.Name] = *chosen
+	cfg.starter = chosen.Name
+	cfg.persist()
+
+	fmt.Printf("You chose %s! It has been added to your Pokedex.\n", chosen.Name)
+	return nil
+}
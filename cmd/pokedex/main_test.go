@@ -1,6 +1,11 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/eqedos/repl/internal/pokeapi"
@@ -59,6 +64,229 @@ func TestCleanInput(t *testing.T) {
 	}
 }
 
+func TestResolveIndex(t *testing.T) {
+	list := []string{"canalave-city-area", "eterna-city-area", "pastoria-city-area"}
+
+	testCases := []struct {
+		name     string
+		list     []string
+		arg      string
+		expected string
+	}{
+		{name: "first index", list: list, arg: "1", expected: "canalave-city-area"},
+		{name: "last index", list: list, arg: "3", expected: "pastoria-city-area"},
+		{name: "zero is out of range", list: list, arg: "0", expected: "0"},
+		{name: "past the end", list: list, arg: "4", expected: "4"},
+		{name: "negative index", list: list, arg: "-1", expected: "-1"},
+		{name: "non-numeric name", list: list, arg: "pikachu", expected: "pikachu"},
+		{name: "empty list", list: nil, arg: "1", expected: "1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolveIndex(tc.list, tc.arg); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClearPokedex(t *testing.T) {
+	newConfig := func(input string, interactive bool) *config {
+		return &config{
+			pokedex: map[string]pokeapi.Pokemon{
+				"pikachu":  {Name: "pikachu"},
+				"magikarp": {Name: "magikarp"},
+			},
+			in:          bufio.NewScanner(strings.NewReader(input)),
+			interactive: interactive,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		input       string
+		interactive bool
+		args        []string
+		remaining   int
+	}{
+		{name: "confirmed interactively", input: "y\n", interactive: true, remaining: 0},
+		{name: "declined interactively", input: "n\n", interactive: true, remaining: 2},
+		{name: "empty answer defaults to no", input: "\n", interactive: true, remaining: 2},
+		{name: "non-interactive without -y", input: "y\n", interactive: false, remaining: 2},
+		{name: "non-interactive with -y", interactive: false, args: []string{"-y"}, remaining: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newConfig(tc.input, tc.interactive)
+			if err := commandClearPokedex(cfg, tc.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(cfg.pokedex) != tc.remaining {
+				t.Errorf("expected %d Pokemon remaining, got %d", tc.remaining, len(cfg.pokedex))
+			}
+		})
+	}
+}
+
+func TestQuietOmitsCacheNotice(t *testing.T) {
+	for _, quiet := range []bool{false, true} {
+		var notices bytes.Buffer
+		client := pokeapi.NewClient(pokeapi.WithNotices(noticesFor(quiet, &notices)), pokeapi.WithOffline())
+
+		// The first location page is part of the embedded snapshot, so this is a cache hit.
+		if _, err := client.GetLocationAreas(client.GetFirstLocationAreasURL()); err != nil {
+			t.Fatalf("GetLocationAreas failed: %v", err)
+		}
+
+		if quiet && notices.Len() != 0 {
+			t.Errorf("expected quiet mode to omit the cache notice, got %q", notices.String())
+		}
+		if !quiet && notices.String() != "(using cached data)\n" {
+			t.Errorf("expected the cache notice, got %q", notices.String())
+		}
+	}
+}
+
+func TestQuietConfirmDefaultsToNo(t *testing.T) {
+	cfg := &config{
+		pokedex:     map[string]pokeapi.Pokemon{"pikachu": {Name: "pikachu"}},
+		in:          bufio.NewScanner(strings.NewReader("y\n")),
+		interactive: true,
+		quiet:       true,
+	}
+
+	if err := commandClearPokedex(cfg, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.pokedex) != 1 {
+		t.Error("expected quiet mode to decline the confirmation")
+	}
+	// The answer must not have been read, since no prompt was shown.
+	if !cfg.in.Scan() || cfg.in.Text() != "y" {
+		t.Error("expected quiet mode not to read a confirmation answer")
+	}
+
+	if err := commandClearPokedex(cfg, []string{"-y"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.pokedex) != 0 {
+		t.Error("expected -y to clear the Pokedex in quiet mode")
+	}
+}
+
+func TestInspectStrict(t *testing.T) {
+	cfg := &config{pokedex: map[string]pokeapi.Pokemon{}}
+
+	if err := commandInspect(cfg, []string{"pikachu"}); err != nil {
+		t.Errorf("expected soft message by default, got error: %v", err)
+	}
+
+	if err := commandInspect(cfg, []string{"pikachu", "--strict"}); err == nil {
+		t.Error("expected an error for an uncaught Pokemon in strict mode")
+	}
+}
+
+func TestCatchDuplicateSkipped(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		interactive bool
+	}{
+		{name: "non-interactive skips by default", interactive: false},
+		{name: "declined interactively", input: "n\n", interactive: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The client is nil: reaching the API would panic.
+			cfg := &config{
+				pokedex:     map[string]pokeapi.Pokemon{"pikachu": {Name: "pikachu", Height: 4}},
+				in:          bufio.NewScanner(strings.NewReader(tc.input)),
+				interactive: tc.interactive,
+			}
+
+			if err := commandCatch(cfg, []string{"pikachu"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.pokedex["pikachu"].Height != 4 {
+				t.Error("expected the existing entry to be kept")
+			}
+		})
+	}
+}
+
+func TestCatchDuplicateConfirmed(t *testing.T) {
+	cfg := &config{
+		client:      newTestClient(t, pokemonAPI(0)),
+		pokedex:     map[string]pokeapi.Pokemon{"pikachu": {Name: "pikachu", Height: 4}},
+		in:          bufio.NewScanner(strings.NewReader("y\n")),
+		interactive: true,
+		rng:         rand.New(rand.NewSource(1)),
+	}
+
+	if err := commandCatch(cfg, []string{"pikachu"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := cfg.pokedex["pikachu"]; !ok || got.Height != 0 {
+		t.Errorf("expected the existing entry to be replaced, got %+v", got)
+	}
+}
+
+func TestMarkExplored(t *testing.T) {
+	var explored []string
+	for _, area := range []string{"eterna-city-area", "canalave-city-area", "eterna-city-area", ""} {
+		explored = markExplored(explored, area)
+	}
+
+	expected := []string{"eterna-city-area", "canalave-city-area"}
+	if !slices.Equal(explored, expected) {
+		t.Errorf("expected %v, got %v", expected, explored)
+	}
+}
+
+func TestWriteHelpHidden(t *testing.T) {
+	commands := map[string]cliCommand{
+		"map":   {name: "map", description: "Lists locations"},
+		"debug": {name: "debug", description: "Advanced debugging", hidden: true},
+	}
+
+	var buf bytes.Buffer
+	writeHelp(&buf, commands, false)
+	if !strings.Contains(buf.String(), "map: Lists locations") {
+		t.Errorf("expected visible command in help, got:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "debug") {
+		t.Errorf("expected hidden command to be omitted, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	writeHelp(&buf, commands, true)
+	if !strings.Contains(buf.String(), "debug: Advanced debugging") {
+		t.Errorf("expected hidden command with --all, got:\n%s", buf.String())
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	testCases := []struct {
+		input    int
+		expected string
+	}{
+		{input: 0, expected: "0 B"},
+		{input: 1023, expected: "1023 B"},
+		{input: 1024, expected: "1.0 KB"},
+		{input: 1536, expected: "1.5 KB"},
+		{input: 5 * 1024 * 1024, expected: "5.0 MB"},
+	}
+
+	for _, tc := range testCases {
+		if got := formatBytes(tc.input); got != tc.expected {
+			t.Errorf("formatBytes(%d): expected %q, got %q", tc.input, tc.expected, got)
+		}
+	}
+}
+
 func TestMapCaching(t *testing.T) {
 	// Initialize client and config
 	client := pokeapi.NewClient()
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// moveClassOrder is the order in which damage classes are displayed.
+var moveClassOrder = []string{"physical", "special", "status"}
+
+// moveGroup holds the names of moves sharing a damage class.
+type moveGroup struct {
+	class string
+	moves []string
+}
+
+// groupMovesByClass groups moves by damage class. Groups are returned in
+// physical, special, status order, followed by any other classes
+// alphabetically; move names within a group are sorted. Nil moves are skipped.
+func groupMovesByClass(moves []*pokeapi.Move) []moveGroup {
+	byClass := make(map[string][]string)
+	for _, move := range moves {
+		if move == nil {
+			continue
+		}
+		class := cmp.Or(move.DamageClass.Name, "unknown")
+		byClass[class] = append(byClass[class], move.Name)
+	}
+
+	classes := slices.Clone(moveClassOrder)
+	var extra []string
+	for class := range byClass {
+		if !slices.Contains(moveClassOrder, class) {
+			extra = append(extra, class)
+		}
+	}
+	slices.Sort(extra)
+	classes = append(classes, extra...)
+
+	groups := make([]moveGroup, 0, len(classes))
+	for _, class := range classes {
+		names := byClass[class]
+		slices.Sort(names)
+		groups = append(groups, moveGroup{class: class, moves: names})
+	}
+	return groups
+}
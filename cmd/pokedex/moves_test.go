@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestGroupMovesByClass(t *testing.T) {
+	move := func(name, class string) *pokeapi.Move {
+		return &pokeapi.Move{Name: name, DamageClass: pokeapi.NamedResource{Name: class}}
+	}
+
+	moves := []*pokeapi.Move{
+		move("thunderbolt", "special"),
+		move("quick-attack", "physical"),
+		move("growl", "status"),
+		nil,
+		move("thunder-shock", "special"),
+		move("agility", "status"),
+	}
+
+	expected := []moveGroup{
+		{class: "physical", moves: []string{"quick-attack"}},
+		{class: "special", moves: []string{"thunder-shock", "thunderbolt"}},
+		{class: "status", moves: []string{"agility", "growl"}},
+	}
+
+	got := groupMovesByClass(moves)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d groups, got %d: %+v", len(expected), len(got), got)
+	}
+	for i, want := range expected {
+		if got[i].class != want.class || !slices.Equal(got[i].moves, want.moves) {
+			t.Errorf("group %d: expected %+v, got %+v", i, want, got[i])
+		}
+	}
+}
+
+func TestGroupMovesByClassEmptyClasses(t *testing.T) {
+	got := groupMovesByClass(nil)
+	if len(got) != 3 {
+		t.Fatalf("expected the three standard classes, got %+v", got)
+	}
+	for _, group := range got {
+		if len(group.moves) != 0 {
+			t.Errorf("expected no moves in %s, got %v", group.class, group.moves)
+		}
+	}
+}
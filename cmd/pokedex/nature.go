@@ -0,0 +1,21 @@
+package main
+
+import "hash/fnv"
+
+// natures lists the 25 Pokemon natures in their in-game index order.
+var natures = []string{
+	"hardy", "lonely", "brave", "adamant", "naughty",
+	"bold", "docile", "relaxed", "impish", "lax",
+	"timid", "hasty", "serious", "jolly", "naive",
+	"modest", "mild", "quiet", "bashful", "rash",
+	"calm", "gentle", "sassy", "careful", "quirky",
+}
+
+// deriveNature returns a pseudo-nature for a Pokemon. The API does not assign
+// natures, so one is derived from a hash of the name; the same name always
+// yields the same nature.
+func deriveNature(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return natures[h.Sum32()%uint32(len(natures))]
+}
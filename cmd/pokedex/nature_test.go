@@ -0,0 +1,34 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeriveNature(t *testing.T) {
+	names := []string{"pikachu", "bulbasaur", "charizard", "magikarp", "mew"}
+
+	for _, name := range names {
+		nature := deriveNature(name)
+		if !slices.Contains(natures, nature) {
+			t.Errorf("%s: %q is not a valid nature", name, nature)
+		}
+		if again := deriveNature(name); again != nature {
+			t.Errorf("%s: expected a stable nature, got %q then %q", name, nature, again)
+		}
+	}
+
+	if len(natures) != 25 {
+		t.Errorf("expected 25 natures, got %d", len(natures))
+	}
+}
+
+func TestDeriveNatureVaries(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, name := range []string{"pikachu", "bulbasaur", "charizard", "magikarp", "mew", "eevee", "snorlax", "gengar"} {
+		seen[deriveNature(name)] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected different names to produce different natures")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// areaProgress records how many of an area's Pokemon are still uncaught.
+type areaProgress struct {
+	name     string
+	total    int
+	uncaught []string
+}
+
+// progressIn returns the Pokemon of area that are not in pokedex, in API order.
+func progressIn(area *pokeapi.LocationAreaResponse, pokedex map[string]pokeapi.Pokemon) areaProgress {
+	names := encounterNames(area)
+	progress := areaProgress{name: area.Name, total: len(names)}
+	for _, name := range names {
+		if _, caught := pokedex[name]; !caught {
+			progress.uncaught = append(progress.uncaught, name)
+		}
+	}
+	return progress
+}
+
+// rankByUncaught returns the progress for every area, those with the most
+// uncaught Pokemon first, breaking ties by name. Nil areas are skipped.
+func rankByUncaught(areas []*pokeapi.LocationAreaResponse, pokedex map[string]pokeapi.Pokemon) []areaProgress {
+	ranked := make([]areaProgress, 0, len(areas))
+	for _, area := range areas {
+		if area != nil {
+			ranked = append(ranked, progressIn(area, pokedex))
+		}
+	}
+	slices.SortFunc(ranked, func(a, b areaProgress) int {
+		return cmp.Or(cmp.Compare(len(b.uncaught), len(a.uncaught)), cmp.Compare(a.name, b.name))
+	})
+	return ranked
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// areaWith returns a location area where the named Pokemon can be encountered.
+func areaWith(name string, pokemon ...string) *pokeapi.LocationAreaResponse {
+	area := &pokeapi.LocationAreaResponse{Name: name}
+	for _, p := range pokemon {
+		area.PokemonEncounters = append(area.PokemonEncounters, pokeapi.PokemonEncounter{
+			Pokemon: pokeapi.NamedResource{Name: p},
+		})
+	}
+	return area
+}
+
+func TestRankByUncaught(t *testing.T) {
+	pokedex := map[string]pokeapi.Pokemon{
+		"zubat":   {Name: "zubat"},
+		"geodude": {Name: "geodude"},
+	}
+	areas := []*pokeapi.LocationAreaResponse{
+		areaWith("cave", "zubat", "geodude", "onix"),
+		nil, // failed to load
+		areaWith("lake", "magikarp", "psyduck", "zubat"),
+		areaWith("forest", "caterpie", "weedle"),
+		areaWith("cleared", "zubat"),
+	}
+
+	ranked := rankByUncaught(areas, pokedex)
+
+	var order []string
+	for _, p := range ranked {
+		order = append(order, p.name)
+	}
+	if expected := []string{"forest", "lake", "cave", "cleared"}; !slices.Equal(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+
+	lake := ranked[1]
+	if lake.total != 3 || !slices.Equal(lake.uncaught, []string{"magikarp", "psyduck"}) {
+		t.Errorf("unexpected progress for lake: %+v", lake)
+	}
+	if cleared := ranked[3]; len(cleared.uncaught) != 0 || cleared.total != 1 {
+		t.Errorf("unexpected progress for cleared: %+v", cleared)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// openCommand returns the program and arguments that open url with the
+// default handler on the given operating system.
+func openCommand(goos, url string) (string, []string, error) {
+	switch goos {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "xdg-open", []string{url}, nil
+	case "darwin":
+		return "open", []string{url}, nil
+	case "windows":
+		return "cmd", []string{"/c", "start", "", url}, nil
+	default:
+		return "", nil, fmt.Errorf("opening URLs is not supported on %s", goos)
+	}
+}
+
+// openURL opens url with the system's default handler without waiting for it
+// to exit. The handler is waited for in the background so that it does not
+// linger as a zombie process.
+func openURL(url string) error {
+	name, args, err := openCommand(runtime.GOOS, url)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	go cmd.Wait()
+	return nil
+}
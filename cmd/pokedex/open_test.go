@@ -0,0 +1,36 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOpenCommand(t *testing.T) {
+	const url = "https://example.com/cry.ogg"
+
+	testCases := []struct {
+		goos         string
+		expectedName string
+		expectedArgs []string
+	}{
+		{goos: "linux", expectedName: "xdg-open", expectedArgs: []string{url}},
+		{goos: "darwin", expectedName: "open", expectedArgs: []string{url}},
+		{goos: "windows", expectedName: "cmd", expectedArgs: []string{"/c", "start", "", url}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.goos, func(t *testing.T) {
+			name, args, err := openCommand(tc.goos, url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != tc.expectedName || !slices.Equal(args, tc.expectedArgs) {
+				t.Errorf("expected %s %v, got %s %v", tc.expectedName, tc.expectedArgs, name, args)
+			}
+		})
+	}
+
+	if _, _, err := openCommand("plan9", url); err == nil {
+		t.Error("expected an error for an unsupported OS")
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import "fmt"
+
+// pageOffset returns the offset of the first entry on a 1-based page.
+func pageOffset(page, size int) int {
+	return (page - 1) * size
+}
+
+// pageCount returns how many pages of size entries hold total entries.
+func pageCount(total, size int) int {
+	return (total + size - 1) / size
+}
+
+// validatePage reports an error for pages outside 1..pages. A total of zero
+// pages only rejects pages below 1, since the total is unknown until fetched.
+func validatePage(page, pages int) error {
+	if page < 1 {
+		return fmt.Errorf("page must be at least 1, got %d", page)
+	}
+	if pages > 0 && page > pages {
+		return fmt.Errorf("page %d is past the end; valid pages are 1-%d", page, pages)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestPageOffset(t *testing.T) {
+	testCases := []struct{ page, expected int }{
+		{page: 1, expected: 0},
+		{page: 2, expected: 20},
+		{page: 55, expected: 1080},
+	}
+	for _, tc := range testCases {
+		if got := pageOffset(tc.page, pokeapi.DefaultPageSize); got != tc.expected {
+			t.Errorf("pageOffset(%d) = %d, expected %d", tc.page, got, tc.expected)
+		}
+	}
+}
+
+func TestPageCount(t *testing.T) {
+	testCases := []struct{ total, expected int }{
+		{total: 0, expected: 0},
+		{total: 1, expected: 1},
+		{total: 20, expected: 1},
+		{total: 21, expected: 2},
+		{total: 1089, expected: 55},
+	}
+	for _, tc := range testCases {
+		if got := pageCount(tc.total, pokeapi.DefaultPageSize); got != tc.expected {
+			t.Errorf("pageCount(%d) = %d, expected %d", tc.total, got, tc.expected)
+		}
+	}
+}
+
+func TestMapPage(t *testing.T) {
+	const total = 45
+
+	// The fake server pages through total areas named area-0, area-1, ...
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			limit = pokeapi.DefaultPageSize
+		}
+		link := func(offset int) string {
+			if offset < 0 || offset >= total {
+				return "null"
+			}
+			return fmt.Sprintf(`"%s/location-area/?offset=%d&limit=%d"`, srv.URL, offset, limit)
+		}
+
+		results := "["
+		for i := offset; i < min(offset+limit, total); i++ {
+			if i > offset {
+				results += ","
+			}
+			results += fmt.Sprintf(`{"name": "area-%d", "url": ""}`, i)
+		}
+		results += "]"
+
+		prev := "null"
+		if offset > 0 {
+			prev = link(max(offset-limit, 0))
+		}
+		fmt.Fprintf(w, `{"count": %d, "next": %s, "previous": %s, "results": %s}`, total, link(offset+limit), prev, results)
+	}))
+	defer srv.Close()
+
+	first := srv.URL + "/location-area/"
+	cfg := &config{
+		client:  pokeapi.NewClient(pokeapi.WithBaseURL(srv.URL), pokeapi.WithoutSnapshot()),
+		nextURL: &first,
+	}
+
+	// The last page is partial and has no next page.
+	if err := commandMap(cfg, []string{"--page", "3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.lastList) != 5 || cfg.lastList[0] != "area-40" {
+		t.Errorf("expected areas 40-44, got %v", cfg.lastList)
+	}
+	if cfg.nextURL != nil {
+		t.Errorf("expected no next page, got %q", *cfg.nextURL)
+	}
+
+	// Relative navigation continues from the jumped-to page.
+	if err := commandMapb(cfg, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.lastList) != 20 || cfg.lastList[0] != "area-20" {
+		t.Errorf("expected areas 20-39 after mapb, got %v", cfg.lastList)
+	}
+
+	// Pages past the end report the valid range and leave the state alone.
+	err := commandMap(cfg, []string{"--page", "4"})
+	if err == nil || err.Error() != "page 4 is past the end; valid pages are 1-3" {
+		t.Errorf("expected a past-the-end error, got %v", err)
+	}
+	if cfg.lastList[0] != "area-20" {
+		t.Errorf("expected the current page to be kept, got %v", cfg.lastList)
+	}
+
+	for _, page := range []string{"0", "-1", "two"} {
+		if err := commandMap(cfg, []string{"--page", page}); err == nil {
+			t.Errorf("expected an error for page %q", page)
+		}
+	}
+}
+
+func TestMapFirstPageUsesSnapshot(t *testing.T) {
+	// The first page is part of the embedded snapshot, so it works offline.
+	cfg := &config{client: pokeapi.NewClient(pokeapi.WithOffline(), pokeapi.WithNotices(io.Discard))}
+
+	if err := commandMap(cfg, []string{"--page", "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.lastList) != pokeapi.DefaultPageSize {
+		t.Errorf("expected a full first page, got %v", cfg.lastList)
+	}
+}
+
+func TestMapPageSize(t *testing.T) {
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.RequestURI())
+		fmt.Fprint(w, `{"count": 45, "next": null, "previous": null, "results": [{"name": "area-10", "url": ""}]}`)
+	}))
+	defer srv.Close()
+
+	cfg := &config{
+		client:   pokeapi.NewClient(pokeapi.WithBaseURL(srv.URL), pokeapi.WithoutSnapshot(), pokeapi.WithNotices(io.Discard)),
+		pageSize: 5,
+	}
+	if err := commandMap(cfg, []string{"--page", "3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requested) != 1 || requested[0] != "/location-area/?offset=10&limit=5" {
+		t.Errorf("expected page 3 of 5 areas to be requested, got %v", requested)
+	}
+
+	// With 5 areas per page, 45 areas make 9 pages.
+	err := commandMap(cfg, []string{"--page", "10"})
+	if err == nil || err.Error() != "page 10 is past the end; valid pages are 1-9" {
+		t.Errorf("expected a past-the-end error, got %v", err)
+	}
+}
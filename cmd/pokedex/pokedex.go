@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// canonicalPokedex re-keys the Pokedex by each Pokemon's canonical API name,
+// so entries stored under different keys for the same Pokemon, such as "25"
+// and "pikachu", become one.
+func canonicalPokedex(pokedex map[string]pokeapi.Pokemon) map[string]pokeapi.Pokemon {
+	byName := make(map[string]pokeapi.Pokemon, len(pokedex))
+	for key, pokemon := range pokedex {
+		name := pokemon.Name
+		if name == "" {
+			name = key
+		}
+		byName[name] = pokemon
+	}
+	return byName
+}
+
+// marshalPokedex encodes the Pokedex as indented JSON keyed by each Pokemon's
+// canonical API name. Keys are emitted in sorted order, so the output is
+// reproducible for the same Pokedex.
+func marshalPokedex(pokedex map[string]pokeapi.Pokemon) ([]byte, error) {
+	data, err := json.MarshalIndent(canonicalPokedex(pokedex), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pokedex: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// exportPokedex writes the full Pokedex to path as JSON and returns the number
+// of Pokemon written, counting entries for the same Pokemon once.
+func exportPokedex(path string, pokedex map[string]pokeapi.Pokemon) (int, error) {
+	byName := canonicalPokedex(pokedex)
+	data, err := marshalPokedex(byName)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return len(byName), nil
+}
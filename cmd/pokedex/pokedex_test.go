@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestExportPokedex(t *testing.T) {
+	pokedex := map[string]pokeapi.Pokemon{
+		"pikachu":  {Name: "pikachu", Height: 4, Stats: []pokeapi.PokemonStat{{BaseStat: 35, Stat: pokeapi.NamedResource{Name: "hp"}}}},
+		"magikarp": {Name: "magikarp", Height: 9},
+	}
+
+	path := filepath.Join(t.TempDir(), "Export.json")
+	count, err := exportPokedex(path, pokedex)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 Pokemon written, got %d", count)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+
+	if strings.Index(string(data), `"magikarp"`) > strings.Index(string(data), `"pikachu"`) {
+		t.Error("expected keys to be sorted")
+	}
+
+	var got map[string]pokeapi.Pokemon
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("export is not valid JSON: %v", err)
+	}
+	if got["pikachu"].Height != 4 || got["pikachu"].Stats[0].BaseStat != 35 {
+		t.Errorf("expected full pikachu data to round-trip, got %+v", got["pikachu"])
+	}
+	if got["magikarp"].Height != 9 {
+		t.Errorf("expected magikarp data to round-trip, got %+v", got["magikarp"])
+	}
+}
+
+func TestExportPokedexCountsMergedEntries(t *testing.T) {
+	// Catching by dex number and by name stores the same Pokemon twice.
+	pokedex := map[string]pokeapi.Pokemon{
+		"25":      {Name: "pikachu"},
+		"pikachu": {Name: "pikachu"},
+	}
+
+	count, err := exportPokedex(filepath.Join(t.TempDir(), "export.json"), pokedex)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 Pokemon written, got %d", count)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// pokemonFrequency records in how many areas a Pokemon appears.
+type pokemonFrequency struct {
+	name  string
+	areas int
+}
+
+// rankByFrequency counts, for every Pokemon, the number of areas it can be
+// encountered in and returns them most common first, breaking ties by name.
+// Nil areas are skipped, and a Pokemon listed twice in one area counts once.
+func rankByFrequency(areas []*pokeapi.LocationAreaResponse) []pokemonFrequency {
+	counts := make(map[string]int)
+	for _, area := range areas {
+		if area == nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, encounter := range area.PokemonEncounters {
+			name := encounter.Pokemon.Name
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			counts[name]++
+		}
+	}
+
+	ranked := make([]pokemonFrequency, 0, len(counts))
+	for name, n := range counts {
+		ranked = append(ranked, pokemonFrequency{name: name, areas: n})
+	}
+	slices.SortFunc(ranked, func(a, b pokemonFrequency) int {
+		return cmp.Or(cmp.Compare(b.areas, a.areas), cmp.Compare(a.name, b.name))
+	})
+	return ranked
+}
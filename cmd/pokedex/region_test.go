@@ -0,0 +1,42 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestRankByFrequency(t *testing.T) {
+	area := func(names ...string) *pokeapi.LocationAreaResponse {
+		resp := &pokeapi.LocationAreaResponse{}
+		for _, name := range names {
+			resp.PokemonEncounters = append(resp.PokemonEncounters, pokeapi.PokemonEncounter{
+				Pokemon: pokeapi.NamedResource{Name: name},
+			})
+		}
+		return resp
+	}
+
+	areas := []*pokeapi.LocationAreaResponse{
+		area("zubat", "geodude", "magikarp"),
+		area("zubat", "bidoof", "zubat"),
+		nil,
+		area("magikarp", "zubat"),
+	}
+
+	expected := []pokemonFrequency{
+		{name: "zubat", areas: 3},
+		{name: "magikarp", areas: 2},
+		{name: "bidoof", areas: 1},
+		{name: "geodude", areas: 1},
+	}
+
+	if got := rankByFrequency(areas); !slices.Equal(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	if got := rankByFrequency(nil); len(got) != 0 {
+		t.Errorf("expected no results for no areas, got %+v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// saveFile is the on-disk format used to persist a session between runs.
+type saveFile struct {
+	Pokedex json.RawMessage `json:"pokedex"`
+	Starter string          `json:"starter,omitempty"`
+	Seen    []string        `json:"seen,omitempty"`
+}
+
+// loadSave reads a saved session from path into cfg. A missing file is not an
+// error; it simply means there is nothing to restore yet.
+func loadSave(path string, cfg *config) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var save saveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	pokedex := make(map[string]pokeapi.Pokemon)
+	if len(save.Pokedex) > 0 {
+		if err := json.Unmarshal(save.Pokedex, &pokedex); err != nil {
+			return fmt.Errorf("failed to parse pokedex in %s: %w", path, err)
+		}
+	}
+
+	cfg.pokedex = pokedex
+	cfg.starter = save.Starter
+	cfg.seen = recordSeen(nil, save.Seen...)
+	return nil
+}
+
+// writeSave writes the session state in cfg to path. The file is replaced
+// atomically so an interrupted write never leaves a truncated save behind.
+func writeSave(path string, cfg *config) error {
+	pokedex, err := marshalPokedex(cfg.pokedex)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(saveFile{
+		Pokedex: pokedex,
+		Starter: cfg.starter,
+		Seen:    sortedSeen(cfg.seen),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
+
+// persist saves the session if persistence is enabled. Failures are reported
+// as warnings rather than failing the command that changed the state.
+func (cfg *config) persist() {
+	if cfg.savePath == "" {
+		return
+	}
+	if err := writeSave(cfg.savePath, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
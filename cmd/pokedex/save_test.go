@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	cfg := &config{
+		pokedex: map[string]pokeapi.Pokemon{
+			"bulbasaur": {Name: "bulbasaur", Height: 7},
+		},
+		starter: "bulbasaur",
+		seen:    map[string]struct{}{"zubat": {}, "geodude": {}},
+	}
+	if err := writeSave(path, cfg); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded := &config{}
+	if err := loadSave(path, loaded); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if loaded.starter != "bulbasaur" {
+		t.Errorf("expected starter bulbasaur, got %q", loaded.starter)
+	}
+	if _, ok := loaded.seen["zubat"]; !ok || len(loaded.seen) != 2 {
+		t.Errorf("expected the seen set to be restored, got %v", loaded.seen)
+	}
+	if loaded.pokedex["bulbasaur"].Height != 7 {
+		t.Errorf("expected bulbasaur to be restored, got %+v", loaded.pokedex)
+	}
+}
+
+func TestLoadSaveMissingFile(t *testing.T) {
+	cfg := &config{pokedex: map[string]pokeapi.Pokemon{"pikachu": {Name: "pikachu"}}}
+
+	if err := loadSave(filepath.Join(t.TempDir(), "missing.json"), cfg); err != nil {
+		t.Fatalf("expected no error for a missing file, got %v", err)
+	}
+	if len(cfg.pokedex) != 1 {
+		t.Error("expected the existing state to be left alone")
+	}
+}
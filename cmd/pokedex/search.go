@@ -0,0 +1,48 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Supported orderings for search results.
+const (
+	sortAlpha  = "alpha"
+	sortLength = "length"
+)
+
+// searchNames returns the names containing substr, ordered by sortBy.
+// Alphabetical order is used by default; "length" orders by name length,
+// breaking ties alphabetically.
+func searchNames(names []string, substr, sortBy string) ([]string, error) {
+	var matches []string
+	for _, name := range names {
+		if strings.Contains(name, substr) {
+			matches = append(matches, name)
+		}
+	}
+
+	switch sortBy {
+	case "", sortAlpha:
+		slices.Sort(matches)
+	case sortLength:
+		slices.SortFunc(matches, func(a, b string) int {
+			return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+		})
+	default:
+		return nil, fmt.Errorf("unknown sort order %q (valid: %s, %s)", sortBy, sortAlpha, sortLength)
+	}
+
+	return matches, nil
+}
+
+// formatSearchResult renders a search match with its dex number when resolve
+// knows it.
+func formatSearchResult(name string, resolve func(string) (int, bool)) string {
+	if id, ok := resolve(name); ok {
+		return fmt.Sprintf("%s (#%d)", name, id)
+	}
+	return name
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchNames(t *testing.T) {
+	names := []string{"charmeleon", "pikachu", "charizard", "charmander", "chatot", "charjabug"}
+
+	testCases := []struct {
+		name     string
+		substr   string
+		sortBy   string
+		expected []string
+	}{
+		{
+			name:     "alphabetical by default",
+			substr:   "char",
+			expected: []string{"charizard", "charjabug", "charmander", "charmeleon"},
+		},
+		{
+			name:     "by length with alphabetical ties",
+			substr:   "char",
+			sortBy:   "length",
+			expected: []string{"charizard", "charjabug", "charmander", "charmeleon"},
+		},
+		{
+			name:     "length ordering differs from alphabetical",
+			substr:   "ch",
+			sortBy:   "length",
+			expected: []string{"chatot", "pikachu", "charizard", "charjabug", "charmander", "charmeleon"},
+		},
+		{
+			name:     "no matches",
+			substr:   "mew",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := searchNames(names, tc.substr, tc.sortBy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	if _, err := searchNames(names, "char", "random"); err == nil {
+		t.Error("expected an error for an unknown sort order")
+	}
+}
+
+func TestFormatSearchResult(t *testing.T) {
+	resolve := func(name string) (int, bool) {
+		id, ok := map[string]int{"pikachu": 25}[name]
+		return id, ok
+	}
+
+	if got := formatSearchResult("pikachu", resolve); got != "pikachu (#25)" {
+		t.Errorf("expected the dex number, got %q", got)
+	}
+	if got := formatSearchResult("missingno", resolve); got != "missingno" {
+		t.Errorf("expected the bare name for an unknown Pokemon, got %q", got)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// recordSeen adds names to the set of Pokemon the user has seen, allocating
+// the set if needed.
+func recordSeen(seen map[string]struct{}, names ...string) map[string]struct{} {
+	if seen == nil {
+		seen = make(map[string]struct{}, len(names))
+	}
+	for _, name := range names {
+		if name != "" {
+			seen[name] = struct{}{}
+		}
+	}
+	return seen
+}
+
+// sortedSeen returns the seen Pokemon names in alphabetical order.
+func sortedSeen(seen map[string]struct{}) []string {
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// completion counts the Pokemon seen and caught. Caught Pokemon count as seen
+// even if they were never surfaced by explore.
+func completion(seen map[string]struct{}, pokedex map[string]pokeapi.Pokemon) (seenCount, caughtCount int) {
+	seenCount = len(seen)
+	for name := range pokedex {
+		if _, ok := seen[name]; !ok {
+			seenCount++
+		}
+	}
+	return seenCount, len(pokedex)
+}
+
+// percent formats part as a percentage of total.
+func percent(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(part)/float64(total))
+}
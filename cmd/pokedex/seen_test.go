@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestRecordSeen(t *testing.T) {
+	seen := recordSeen(nil, "zubat", "geodude")
+	seen = recordSeen(seen, "zubat", "", "bidoof")
+
+	expected := []string{"bidoof", "geodude", "zubat"}
+	if got := sortedSeen(seen); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	seen := map[string]struct{}{"zubat": {}, "geodude": {}, "bidoof": {}}
+	pokedex := map[string]pokeapi.Pokemon{
+		"zubat":   {Name: "zubat"},
+		"pikachu": {Name: "pikachu"},
+	}
+
+	seenCount, caughtCount := completion(seen, pokedex)
+	if seenCount != 4 || caughtCount != 2 {
+		t.Errorf("expected 4 seen and 2 caught, got %d seen and %d caught", seenCount, caughtCount)
+	}
+
+	if got := percent(1, 4); got != "25.0%" {
+		t.Errorf("expected 25.0%%, got %s", got)
+	}
+	if got := percent(1, 0); got != "0.0%" {
+		t.Errorf("expected 0.0%% for an empty total, got %s", got)
+	}
+}
+
+func TestExploreSavesOnlyNewlySeen(t *testing.T) {
+	cfg := &config{
+		client:   newTestClient(t, fixtureAPI(t, "location_area.json")),
+		pokedex:  make(map[string]pokeapi.Pokemon),
+		savePath: filepath.Join(t.TempDir(), "save.json"),
+	}
+
+	if err := commandExplore(cfg, []string{"fixture-area"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(cfg.savePath); err != nil {
+		t.Fatalf("expected newly seen Pokemon to be saved: %v", err)
+	}
+
+	// Exploring the same area again surfaces nothing new, so nothing is written.
+	if err := os.Remove(cfg.savePath); err != nil {
+		t.Fatalf("failed to remove save: %v", err)
+	}
+	if err := commandExplore(cfg, []string{"fixture-area"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(cfg.savePath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected no save when nothing changed, got %v", err)
+	}
+}
+
+func TestExploreSeesTruncatedPokemon(t *testing.T) {
+	cfg := &config{
+		client:    newTestClient(t, fixtureAPI(t, "location_area.json")),
+		pokedex:   make(map[string]pokeapi.Pokemon),
+		listLimit: 2,
+	}
+
+	if err := commandExplore(cfg, []string{"fixture-area", "--details"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.lastList) != 2 {
+		t.Fatalf("expected the listing to be truncated to 2, got %v", cfg.lastList)
+	}
+
+	expected := []string{"bidoof", "hoothoot", "magikarp", "tentacool"}
+	if got := sortedSeen(cfg.seen); !slices.Equal(got, expected) {
+		t.Errorf("expected every Pokemon in the area to be seen, got %v", got)
+	}
+}
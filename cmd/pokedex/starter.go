@@ -0,0 +1,38 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+// maxDexID is the highest National Pokedex number offered as a starter.
+const maxDexID = 1025
+
+// starterChoices is the number of starters offered at once.
+const starterChoices = 3
+
+// pickStarterIDs returns n distinct dex IDs in the range [1, maxID].
+func pickStarterIDs(rng *rand.Rand, n, maxID int) []int {
+	n = min(n, maxID)
+	seen := make(map[int]bool, n)
+	ids := make([]int, 0, n)
+	for len(ids) < n {
+		id := rng.Intn(maxID) + 1
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// statTotal returns the sum of a Pokemon's base stats.
+func statTotal(pokemon *pokeapi.Pokemon) int {
+	total := 0
+	for _, stat := range pokemon.Stats {
+		total += stat.BaseStat
+	}
+	return total
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"math/rand"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestPickStarterIDs(t *testing.T) {
+	first := pickStarterIDs(rand.New(rand.NewSource(42)), 3, maxDexID)
+	second := pickStarterIDs(rand.New(rand.NewSource(42)), 3, maxDexID)
+
+	if !slices.Equal(first, second) {
+		t.Errorf("expected the same seed to produce the same IDs, got %v and %v", first, second)
+	}
+
+	if len(first) != 3 {
+		t.Fatalf("expected 3 IDs, got %v", first)
+	}
+	for _, id := range first {
+		if id < 1 || id > maxDexID {
+			t.Errorf("ID %d out of range", id)
+		}
+	}
+
+	// With only three possible IDs every one of them must be chosen exactly once.
+	ids := pickStarterIDs(rand.New(rand.NewSource(1)), 3, 3)
+	slices.Sort(ids)
+	if !slices.Equal(ids, []int{1, 2, 3}) {
+		t.Errorf("expected distinct IDs 1-3, got %v", ids)
+	}
+}
+
+func TestStatTotal(t *testing.T) {
+	pokemon := &pokeapi.Pokemon{Stats: []pokeapi.PokemonStat{
+		{BaseStat: 45}, {BaseStat: 49}, {BaseStat: 49}, {BaseStat: 65}, {BaseStat: 65}, {BaseStat: 45},
+	}}
+	if got := statTotal(pokemon); got != 318 {
+		t.Errorf("expected 318, got %d", got)
+	}
+}
+
+func TestCommandStarter(t *testing.T) {
+	const seed = 9
+
+	// Replay the seed to learn which dex numbers are offered.
+	ids := pickStarterIDs(rand.New(rand.NewSource(seed)), starterChoices, maxDexID)
+	expected := fmt.Sprintf("mon-%d", ids[1])
+
+	cfg := &config{
+		client: newTestClient(t, fakeAPI(func(req *http.Request) (string, error) {
+			// Pokemon are requested by dex number and named after it.
+			id := path.Base(req.URL.Path)
+			return fmt.Sprintf(`{"id": %s, "name": "mon-%s", "stats": [{"base_stat": 50}]}`, id, id), nil
+		})),
+		pokedex: make(map[string]pokeapi.Pokemon),
+		in:      bufio.NewScanner(strings.NewReader("2\n")),
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+	if err := commandStarter(cfg, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.starter != expected {
+		t.Errorf("expected %s as the starter, got %q", expected, cfg.starter)
+	}
+	if _, ok := cfg.pokedex[expected]; !ok || len(cfg.pokedex) != 1 {
+		t.Errorf("expected only %s in the Pokedex, got %v", expected, slices.Collect(maps.Keys(cfg.pokedex)))
+	}
+}
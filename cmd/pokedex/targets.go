@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readTargets reads Pokemon names from r, one per line. Blank lines and lines
+// starting with "#" are skipped; names are trimmed and lowercased.
+func readTargets(r io.Reader) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, strings.ToLower(line))
+	}
+	return names, scanner.Err()
+}
+
+// loadTargets reads the Pokemon names listed in the file at path.
+func loadTargets(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open targets: %w", err)
+	}
+	defer f.Close()
+
+	names, err := readTargets(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read targets: %w", err)
+	}
+	return names, nil
+}
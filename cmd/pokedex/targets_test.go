@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eqedos/repl/internal/pokeapi"
+)
+
+func TestReadTargets(t *testing.T) {
+	input := "# route 1 targets\nPikachu\n\n  charizard  \n# done\n"
+
+	names, err := readTargets(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"pikachu", "charizard"}; !slices.Equal(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestImportTargets(t *testing.T) {
+	const seed = 3
+
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	content := "# snapshot Pokemon, so no network is needed\npikachu\n\nmissingno\ncharizard\npikachu\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write targets: %v", err)
+	}
+
+	// Replay the seed: missingno fails to load and never rolls, and the
+	// duplicate pikachu only rolls if the first one escaped.
+	replay := rand.New(rand.NewSource(seed))
+	expected := make(map[string]bool)
+	expected["pikachu"] = catchRoll(replay, 112)
+	expected["charizard"] = catchRoll(replay, 267)
+	if !expected["pikachu"] {
+		expected["pikachu"] = catchRoll(replay, 112)
+	}
+
+	cfg := &config{
+		client:  pokeapi.NewClient(pokeapi.WithOffline()),
+		pokedex: make(map[string]pokeapi.Pokemon),
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+	if err := commandImportTargets(cfg, []string{path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, caught := range expected {
+		if _, ok := cfg.pokedex[name]; ok != caught {
+			t.Errorf("%s: expected caught=%v, got %v", name, caught, ok)
+		}
+	}
+	if _, ok := cfg.pokedex["missingno"]; ok {
+		t.Error("expected missingno not to be caught")
+	}
+
+	if err := commandImportTargets(cfg, []string{filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
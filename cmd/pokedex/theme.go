@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// namePlaceholder is replaced with the Pokemon's name in theme messages.
+const namePlaceholder = "{name}"
+
+// theme holds the flavor text printed while catching. Empty messages fall
+// back to defaultTheme, so the zero theme prints the default wording.
+type theme struct {
+	Throw   string `json:"throw"`
+	Caught  string `json:"caught"`
+	Escaped string `json:"escaped"`
+}
+
+// defaultTheme is the built-in catch wording.
+var defaultTheme = theme{
+	Throw:   "Throwing a Pokeball at {name}...",
+	Caught:  "{name} was caught!",
+	Escaped: "{name} escaped!",
+}
+
+// throw returns the message printed before a catch attempt.
+func (t theme) throw(name string) string {
+	return fillName(cmp.Or(t.Throw, defaultTheme.Throw), name)
+}
+
+// caught returns the message printed when a Pokemon is caught.
+func (t theme) caught(name string) string {
+	return fillName(cmp.Or(t.Caught, defaultTheme.Caught), name)
+}
+
+// escaped returns the message printed when a Pokemon escapes.
+func (t theme) escaped(name string) string {
+	return fillName(cmp.Or(t.Escaped, defaultTheme.Escaped), name)
+}
+
+// fillName substitutes name for every placeholder in msg.
+func fillName(msg, name string) string {
+	return strings.ReplaceAll(msg, namePlaceholder, name)
+}
+
+// validate reports an error for messages that do not mention the Pokemon.
+func (t theme) validate() error {
+	messages := []struct{ field, msg string }{
+		{"throw", t.Throw},
+		{"caught", t.Caught},
+		{"escaped", t.Escaped},
+	}
+	for _, m := range messages {
+		if m.msg != "" && !strings.Contains(m.msg, namePlaceholder) {
+			return fmt.Errorf("theme message %q must contain %s", m.field, namePlaceholder)
+		}
+	}
+	return nil
+}
+
+// loadTheme reads a theme from a JSON file. Messages the file leaves out keep
+// their default wording; unknown keys are rejected to catch typos.
+func loadTheme(path string) (theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return theme{}, fmt.Errorf("failed to read theme: %w", err)
+	}
+
+	var t theme
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return theme{}, fmt.Errorf("failed to parse theme %s: %w", path, err)
+	}
+	if err := t.validate(); err != nil {
+		return theme{}, fmt.Errorf("invalid theme %s: %w", path, err)
+	}
+	return t, nil
+}
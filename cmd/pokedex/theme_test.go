@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultTheme(t *testing.T) {
+	var th theme
+
+	if got := th.throw("pikachu"); got != "Throwing a Pokeball at pikachu..." {
+		t.Errorf("unexpected throw message: %q", got)
+	}
+	if got := th.caught("pikachu"); got != "pikachu was caught!" {
+		t.Errorf("unexpected caught message: %q", got)
+	}
+	if got := th.escaped("pikachu"); got != "pikachu escaped!" {
+		t.Errorf("unexpected escaped message: %q", got)
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write theme: %v", err)
+		}
+		return path
+	}
+
+	th, err := loadTheme(write("custom.json", `{"throw": "Go, Ultra Ball! ({name})", "caught": "Gotcha! {name} was caught!"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := th.throw("eevee"); got != "Go, Ultra Ball! (eevee)" {
+		t.Errorf("unexpected throw message: %q", got)
+	}
+	if got := th.caught("eevee"); got != "Gotcha! eevee was caught!" {
+		t.Errorf("unexpected caught message: %q", got)
+	}
+	// Messages left out of the file keep the default wording.
+	if got := th.escaped("eevee"); got != "eevee escaped!" {
+		t.Errorf("unexpected escaped message: %q", got)
+	}
+
+	invalid := map[string]string{
+		"syntax.json":      `{"throw": `,
+		"unknown.json":     `{"thrown": "{name}"}`,
+		"no-name.json":     `{"escaped": "It got away!"}`,
+		"wrong-type.json":  `{"caught": 42}`,
+		"missing-file.txt": "",
+	}
+	for name, content := range invalid {
+		path := filepath.Join(dir, name)
+		if content != "" {
+			path = write(name, content)
+		}
+		if _, err := loadTheme(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// defaultListLimit is how many entries long listings show unless --all is given.
+const defaultListLimit = 25
+
+// truncateList returns at most limit items from the start of items and the
+// number of items left out. A limit of zero or less keeps every item.
+func truncateList[T any](items []T, limit int) ([]T, int) {
+	if limit <= 0 || len(items) <= limit {
+		return items, 0
+	}
+	return items[:limit], len(items) - limit
+}
+
+// writeTruncationNotice tells the user how many entries were left out, if any.
+func writeTruncationNotice(w io.Writer, hidden int) {
+	if hidden > 0 {
+		fmt.Fprintf(w, "  ... and %d more (use --all to see everything)\n", hidden)
+	}
+}
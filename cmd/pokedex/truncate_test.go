@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestTruncateList(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+
+	testCases := []struct {
+		limit    int
+		expected []string
+		hidden   int
+	}{
+		{limit: 2, expected: []string{"a", "b"}, hidden: 2},
+		{limit: 4, expected: items, hidden: 0},
+		{limit: 10, expected: items, hidden: 0},
+		{limit: 0, expected: items, hidden: 0},
+	}
+
+	for _, tc := range testCases {
+		got, hidden := truncateList(items, tc.limit)
+		if !slices.Equal(got, tc.expected) || hidden != tc.hidden {
+			t.Errorf("limit %d: expected %v with %d hidden, got %v with %d hidden", tc.limit, tc.expected, tc.hidden, got, hidden)
+		}
+	}
+}
+
+func TestWriteTruncationNotice(t *testing.T) {
+	var buf bytes.Buffer
+	writeTruncationNotice(&buf, 0)
+	if buf.Len() != 0 {
+		t.Errorf("expected no notice when nothing is hidden, got %q", buf.String())
+	}
+
+	writeTruncationNotice(&buf, 3)
+	if expected := "  ... and 3 more (use --all to see everything)\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
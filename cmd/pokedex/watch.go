@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// minWatchInterval is the shortest interval accepted by the watch command.
+const minWatchInterval = 100 * time.Millisecond
+
+// clearScreen is the ANSI sequence that moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// parseInterval parses a watch interval given either as a Go duration
+// ("500ms", "2s", "1m") or as a plain number of seconds.
+func parseInterval(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		secs, convErr := strconv.ParseFloat(s, 64)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid interval %q (e.g., '2s' or '5')", s)
+		}
+		d = time.Duration(secs * float64(time.Second))
+	}
+	if d < minWatchInterval {
+		return 0, fmt.Errorf("interval must be at least %s", minWatchInterval)
+	}
+	return d, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{input: "2s", expected: 2 * time.Second},
+		{input: "500ms", expected: 500 * time.Millisecond},
+		{input: "5", expected: 5 * time.Second},
+		{input: "1.5", expected: 1500 * time.Millisecond},
+	}
+
+	for _, tc := range testCases {
+		got, err := parseInterval(tc.input)
+		if err != nil {
+			t.Errorf("parseInterval(%q): unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("parseInterval(%q): expected %s, got %s", tc.input, tc.expected, got)
+		}
+	}
+
+	for _, input := range []string{"", "soon", "0", "10ms", "-1s"} {
+		if _, err := parseInterval(input); err == nil {
+			t.Errorf("parseInterval(%q): expected an error", input)
+		}
+	}
+}
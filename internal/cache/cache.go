@@ -2,6 +2,8 @@
 package cache
 
 import (
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -15,9 +17,11 @@ type Cache struct {
 }
 
 // entry represents a single cached item with its creation timestamp.
+// A zero ttl means the cache's default TTL applies.
 type entry struct {
 	createdAt time.Time
 	data      []byte
+	ttl       time.Duration
 }
 
 // New creates a new Cache instance with the specified TTL duration.
@@ -43,16 +47,113 @@ func (c *Cache) Add(key string, data []byte) {
 	}
 }
 
+// AddWithTTL stores a value like Add, but expires it after ttl instead of
+// the cache's default TTL.
+func (c *Cache) AddWithTTL(key string, data []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry{
+		createdAt: time.Now(),
+		data:      data,
+		ttl:       ttl,
+	}
+}
+
 // Get retrieves a value from the cache by key.
-// Returns the value and true if found, or nil and false if not present.
+// Returns the value and true if found, or nil and false if not present or
+// expired. Expired entries are treated as misses even before they are reaped.
 func (c *Cache) Get(key string) ([]byte, bool) {
+	data, _, ok := c.GetWithAge(key)
+	return data, ok
+}
+
+// GetWithAge retrieves a value like Get and also reports how long ago it was stored.
+func (c *Cache) GetWithAge(key string) ([]byte, time.Duration, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	e, ok := c.entries[key]
 	if !ok {
-		return nil, false
+		return nil, 0, false
+	}
+	age := time.Since(e.createdAt)
+	if age > e.lifetime(c.ttl) {
+		return nil, 0, false
 	}
-	return e.data, true
+	return e.data, age, true
+}
+
+// Range calls fn for every unexpired entry with its key, value, and age, in
+// no particular order, stopping early if fn returns false. The cache is read
+// locked during the iteration, so fn must not modify the cache.
+func (c *Cache) Range(fn func(key string, data []byte, age time.Duration) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for key, e := range c.entries {
+		age := time.Since(e.createdAt)
+		if age > e.lifetime(c.ttl) {
+			continue
+		}
+		if !fn(key, e.data, age) {
+			return
+		}
+	}
+}
+
+// Len returns the number of unexpired entries held in the cache.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	n := 0
+	for _, e := range c.entries {
+		if time.Since(e.createdAt) <= e.lifetime(c.ttl) {
+			n++
+		}
+	}
+	return n
+}
+
+// Bytes returns the approximate memory footprint of the unexpired entries,
+// computed as the sum of their value sizes.
+func (c *Cache) Bytes() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	total := 0
+	for _, e := range c.entries {
+		if time.Since(e.createdAt) <= e.lifetime(c.ttl) {
+			total += len(e.data)
+		}
+	}
+	return total
+}
+
+// KeysWithPrefix returns the keys of unexpired entries that start with
+// prefix, in sorted order.
+func (c *Cache) KeysWithPrefix(prefix string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var keys []string
+	for key, e := range c.entries {
+		if strings.HasPrefix(key, prefix) && time.Since(e.createdAt) <= e.lifetime(c.ttl) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// DeletePrefix removes every entry whose key starts with prefix and returns
+// the number of entries removed.
+func (c *Cache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := 0
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+			removed++
+		}
+	}
+	return removed
 }
 
 // reapLoop runs in a background goroutine to periodically remove expired entries.
@@ -63,10 +164,18 @@ func (c *Cache) reapLoop() {
 	for range ticker.C {
 		c.mu.Lock()
 		for key, e := range c.entries {
-			if time.Since(e.createdAt) > c.ttl {
+			if time.Since(e.createdAt) > e.lifetime(c.ttl) {
 				delete(c.entries, key)
 			}
 		}
 		c.mu.Unlock()
 	}
 }
+
+// lifetime returns how long the entry lives, falling back to def when it has no TTL of its own.
+func (e entry) lifetime(def time.Duration) time.Duration {
+	if e.ttl > 0 {
+		return e.ttl
+	}
+	return def
+}
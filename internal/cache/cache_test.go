@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"slices"
 	"testing"
 	"time"
 )
@@ -50,3 +51,151 @@ func TestCacheOverwrite(t *testing.T) {
 		t.Errorf("expected %q, got %q", "second", string(got))
 	}
 }
+
+func TestCacheLenAndBytes(t *testing.T) {
+	c := New(5 * time.Minute)
+
+	if c.Len() != 0 || c.Bytes() != 0 {
+		t.Fatalf("expected empty cache, got %d entries and %d bytes", c.Len(), c.Bytes())
+	}
+
+	c.Add("a", []byte("12345"))
+	c.Add("b", []byte("123"))
+	c.Add("a", []byte("1"))
+
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+
+	if c.Bytes() != 4 {
+		t.Errorf("expected 4 bytes, got %d", c.Bytes())
+	}
+}
+
+func TestCacheAddWithTTL(t *testing.T) {
+	c := New(10 * time.Millisecond)
+
+	c.Add("short", []byte("default ttl"))
+	c.AddWithTTL("long", []byte("long ttl"), time.Hour)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("expected entry with the default TTL to be reaped")
+	}
+	if got, ok := c.Get("long"); !ok || string(got) != "long ttl" {
+		t.Errorf("expected entry with a long TTL to survive, got %q (found: %v)", got, ok)
+	}
+}
+
+func TestCacheShortTTLExpiresBeforeReap(t *testing.T) {
+	// The reaper only runs every hour, so expiry must be checked on read.
+	c := New(time.Hour)
+	c.AddWithTTL("short", []byte("short ttl"), 10*time.Millisecond)
+
+	if _, ok := c.Get("short"); !ok {
+		t.Fatal("expected the entry before it expires")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("expected Get to miss an expired entry")
+	}
+	if _, _, ok := c.GetWithAge("short"); ok {
+		t.Error("expected GetWithAge to miss an expired entry")
+	}
+	c.Range(func(key string, _ []byte, _ time.Duration) bool {
+		t.Errorf("expected Range to skip the expired entry, got %s", key)
+		return true
+	})
+	if c.Len() != 0 || c.Bytes() != 0 {
+		t.Errorf("expected Len and Bytes to skip the expired entry, got %d entries and %d bytes", c.Len(), c.Bytes())
+	}
+}
+
+func TestCacheKeysWithPrefix(t *testing.T) {
+	c := New(5 * time.Minute)
+	c.Add("https://example.com/pokemon/pikachu/", []byte("a"))
+	c.Add("https://example.com/pokemon/bulbasaur/", []byte("b"))
+	c.Add("https://example.com/pokemon-species/pikachu/", []byte("c"))
+	c.Add("https://example.com/location-area/", []byte("d"))
+	c.AddWithTTL("https://example.com/pokemon/expired/", []byte("e"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	expected := []string{
+		"https://example.com/pokemon/bulbasaur/",
+		"https://example.com/pokemon/pikachu/",
+	}
+	if got := c.KeysWithPrefix("https://example.com/pokemon/"); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := c.KeysWithPrefix("https://other.example/"); len(got) != 0 {
+		t.Errorf("expected no keys, got %v", got)
+	}
+}
+
+func TestCacheDeletePrefix(t *testing.T) {
+	c := New(5 * time.Minute)
+	c.Add("https://example.com/pokemon/pikachu/", []byte("a"))
+	c.Add("https://example.com/pokemon/bulbasaur/", []byte("b"))
+	c.Add("https://example.com/location-area/", []byte("c"))
+
+	if removed := c.DeletePrefix("https://example.com/pokemon/"); removed != 2 {
+		t.Errorf("expected 2 entries removed, got %d", removed)
+	}
+	if _, ok := c.Get("https://example.com/pokemon/pikachu/"); ok {
+		t.Error("expected pikachu to be evicted")
+	}
+	if _, ok := c.Get("https://example.com/location-area/"); !ok {
+		t.Error("expected location data to be kept")
+	}
+
+	if removed := c.DeletePrefix("https://example.com/pokemon/"); removed != 0 {
+		t.Errorf("expected nothing left to remove, got %d", removed)
+	}
+}
+
+func TestCacheGetWithAge(t *testing.T) {
+	c := New(5 * time.Minute)
+	c.Add("key", []byte("data"))
+	time.Sleep(2 * time.Millisecond)
+
+	data, age, ok := c.GetWithAge("key")
+	if !ok || string(data) != "data" {
+		t.Fatalf("expected cached data, got %q (ok=%v)", data, ok)
+	}
+	if age < 2*time.Millisecond {
+		t.Errorf("expected an age of at least 2ms, got %s", age)
+	}
+
+	if _, _, ok := c.GetWithAge("missing"); ok {
+		t.Error("expected a miss for an unknown key")
+	}
+}
+
+func TestCacheRange(t *testing.T) {
+	c := New(5 * time.Minute)
+	c.Add("a", []byte("1"))
+	c.Add("b", []byte("22"))
+	c.Add("c", []byte("333"))
+
+	sizes := make(map[string]int)
+	c.Range(func(key string, data []byte, age time.Duration) bool {
+		sizes[key] = len(data)
+		return true
+	})
+	if len(sizes) != 3 || sizes["a"] != 1 || sizes["b"] != 2 || sizes["c"] != 3 {
+		t.Errorf("unexpected entries visited: %v", sizes)
+	}
+
+	visited := 0
+	c.Range(func(string, []byte, time.Duration) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("expected Range to stop after the first entry, visited %d", visited)
+	}
+}
@@ -0,0 +1,53 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// fetchAll calls get for every key, such as a name or ID, with at most
+// c.concurrency calls in flight.
+// Results are returned in input order. Failed entries are left nil and their
+// errors are joined in the returned error. get receives ctx so that calls in
+// flight are abandoned once it is done; no further calls are started then,
+// the remaining entries are left nil, and the context's error is included in
+// the returned error.
+func fetchAll[K any, T any](ctx context.Context, c *Client, keys []K, get func(context.Context, K) (*T, error)) ([]*T, error) {
+	results := make([]*T, len(keys))
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, c.concurrency)
+
+	var wg sync.WaitGroup
+	launched := 0
+	for i, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		launched++
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := get(ctx, key)
+			if err != nil {
+				errs[i] = fmt.Errorf("%v: %w", key, err)
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if launched < len(keys) {
+		errs = append(errs, ctx.Err())
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,179 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// concurrencyTransport answers move requests and tracks the peak number of
+// requests in flight.
+type concurrencyTransport struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (rt *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.inFlight++
+	rt.peak = max(rt.peak, rt.inFlight)
+	rt.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	rt.mu.Lock()
+	rt.inFlight--
+	rt.mu.Unlock()
+
+	name := path.Base(req.URL.Path)
+	status := http.StatusOK
+	body := fmt.Sprintf(`{"name": %q, "damage_class": {"name": "physical"}}`, name)
+	if name == "missing" {
+		status, body = http.StatusNotFound, "Not Found"
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestGetMovesConcurrency(t *testing.T) {
+	rt := &concurrencyTransport{}
+	client := NewClient(WithTransport(rt), WithConcurrency(2))
+
+	names := []string{"tackle", "growl", "ember", "missing", "scratch", "leer"}
+	moves, err := client.GetMoves(names)
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected an error mentioning the missing move, got %v", err)
+	}
+
+	for i, name := range names {
+		if name == "missing" {
+			if moves[i] != nil {
+				t.Errorf("expected nil result for the missing move, got %+v", moves[i])
+			}
+			continue
+		}
+		if moves[i] == nil || moves[i].Name != name {
+			t.Errorf("result %d: expected %q, got %+v", i, name, moves[i])
+		}
+	}
+
+	if rt.peak > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", rt.peak)
+	}
+}
+
+// cancellingTransport answers Pokemon requests and cancels a context when the
+// named Pokemon is requested.
+type cancellingTransport struct {
+	mu       sync.Mutex
+	requests []string
+	cancelAt string
+	cancel   context.CancelFunc
+}
+
+func (rt *cancellingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := path.Base(req.URL.Path)
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, name)
+	rt.mu.Unlock()
+	if name == rt.cancelAt {
+		rt.cancel()
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"name": %q}`, name))),
+		Request:    req,
+	}, nil
+}
+
+func TestGetPokemonBatchContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rt := &cancellingTransport{cancelAt: "ivysaur", cancel: cancel}
+	client := NewClient(WithTransport(rt), WithoutSnapshot(), WithConcurrency(1))
+
+	names := []string{"bulbasaur", "ivysaur", "venusaur", "charmander"}
+	pokemon, err := client.GetPokemonBatchContext(ctx, names)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+
+	// The request in flight when the context was cancelled still completes.
+	for i, name := range names[:2] {
+		if pokemon[i] == nil || pokemon[i].Name != name {
+			t.Errorf("result %d: expected %q, got %+v", i, name, pokemon[i])
+		}
+	}
+	for i := 2; i < len(names); i++ {
+		if pokemon[i] != nil {
+			t.Errorf("result %d: expected nil after cancellation, got %+v", i, pokemon[i])
+		}
+	}
+	if len(rt.requests) != 2 {
+		t.Errorf("expected no requests after cancellation, got %v", rt.requests)
+	}
+}
+
+func TestGetPokemonByIDs(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu"}`}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	pokemon, err := client.GetPokemonByIDs([]int{25, 6})
+	if err != nil {
+		t.Fatalf("GetPokemonByIDs failed: %v", err)
+	}
+	if len(pokemon) != 2 || pokemon[0] == nil || pokemon[1] == nil {
+		t.Fatalf("expected two Pokemon, got %v", pokemon)
+	}
+
+	slices.Sort(rt.urls)
+	expected := []string{BaseURL + "/pokemon/25/", BaseURL + "/pokemon/6/"}
+	if !slices.Equal(rt.urls, expected) {
+		t.Errorf("expected requests %v, got %v", expected, rt.urls)
+	}
+}
+
+// hangingTransport never answers; it returns only once the request's context is done.
+type hangingTransport struct{}
+
+func (hangingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestGetPokemonBatchContextAbandonsRequestsInFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(WithTransport(hangingTransport{}), WithoutSnapshot())
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetPokemonBatchContext(ctx, []string{"bulbasaur", "ivysaur"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected a cancellation error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the hung requests to be abandoned after cancellation")
+	}
+}
@@ -1,10 +1,16 @@
 package pokeapi
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/eqedos/repl/internal/cache"
@@ -14,50 +20,184 @@ const (
 	// BaseURL is the base URL for the PokeAPI.
 	BaseURL = "https://pokeapi.co/api/v2"
 
+	// pokemonListLimit is large enough to fetch every Pokemon in a single page.
+	pokemonListLimit = 100000
+
 	// DefaultCacheTTL is the default time-to-live for cached responses.
 	DefaultCacheTTL = 5 * time.Minute
+
+	// DefaultPageSize is the number of entries the API returns per page of a
+	// list when no limit is given.
+	DefaultPageSize = 20
+
+	// DefaultConcurrency is the default number of requests a batch fetch
+	// may have in flight at once.
+	DefaultConcurrency = 5
 )
 
+// ErrOffline is returned when the client is offline and a response is not cached.
+var ErrOffline = errors.New("offline")
+
 // Client handles communication with the PokeAPI.
 type Client struct {
-	cache   *cache.Cache
-	baseURL string
+	cache        *cache.Cache
+	baseURL      string
+	httpClient   *http.Client
+	metrics      Metrics
+	offline      bool
+	concurrency  int
+	snapshot     bool
+	cacheTTL     time.Duration
+	index        *nameIndex
+	strictJSON   bool
+	endpointTTLs map[string]time.Duration
+	flights      *flightGroup
+	counters     *counters
+	latencies    *latencyRecorder
+	notices      io.Writer
+	fresh        bool
+}
+
+// Option configures optional Client behavior.
+type Option func(*Client)
+
+// WithTransport sets the http.RoundTripper used for outgoing requests.
+// It can be used to inject headers, logging, or instrumentation.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithOffline prevents the client from making network requests.
+// Only cached responses are served; cache misses return ErrOffline.
+func WithOffline() Option {
+	return func(c *Client) {
+		c.offline = true
+	}
+}
+
+// WithConcurrency limits how many requests batch fetches may have in flight at once.
+// Values below 1 are ignored.
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithNotices sets where informational notices, such as when a response is
+// served from the cache, are written. Pass io.Discard to silence them.
+func WithNotices(w io.Writer) Option {
+	return func(c *Client) {
+		c.notices = w
+	}
+}
+
+// WithStrictJSON makes response parsing fail on fields that the response
+// types do not declare. This is useful for validating the types against live
+// data; it is off by default because the API adds fields over time.
+//
+// Only types that declare the endpoint's full schema are checked: the
+// location area and Pokemon responses and lists. Move, PokemonSpecies, and
+// EvolutionChain declare just the fields the REPL uses and are always decoded
+// leniently.
+func WithStrictJSON() Option {
+	return func(c *Client) {
+		c.strictJSON = true
+	}
 }
 
 // NewClient creates a new PokeAPI client with caching enabled.
-func NewClient() *Client {
-	return &Client{
-		cache:   cache.New(DefaultCacheTTL),
-		baseURL: BaseURL,
+// The cache is preloaded with the embedded snapshot unless WithoutSnapshot is given.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		cache:        cache.New(DefaultCacheTTL),
+		baseURL:      BaseURL,
+		httpClient:   &http.Client{},
+		metrics:      noopMetrics{},
+		concurrency:  DefaultConcurrency,
+		snapshot:     true,
+		cacheTTL:     DefaultCacheTTL,
+		index:        &nameIndex{},
+		endpointTTLs: newEndpointTTLs(),
+		flights:      &flightGroup{},
+		counters:     &counters{},
+		latencies:    &latencyRecorder{},
+		notices:      os.Stdout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.snapshot {
+		c.loadSnapshot()
 	}
+	return c
+}
+
+// Cache returns the response cache used by the client.
+func (c *Client) Cache() *cache.Cache {
+	return c.cache
+}
+
+// Fresh returns a client that skips cache reads and always fetches from the
+// API. It shares the cache with c, so fresh responses still populate it.
+func (c *Client) Fresh() *Client {
+	fresh := *c
+	fresh.fresh = true
+	return &fresh
+}
+
+// Silent returns a client that writes no notices. It shares the cache and
+// request counters with c.
+func (c *Client) Silent() *Client {
+	silent := *c
+	silent.notices = io.Discard
+	return &silent
 }
 
 // GetLocationAreas fetches a paginated list of location areas from the given URL.
+// The Next and Previous links are rewritten to the client's base URL host.
 func (c *Client) GetLocationAreas(url string) (*LocationAreasResponse, error) {
-	data, err := c.fetchWithCache(url)
+	return c.GetLocationAreasContext(context.Background(), url)
+}
+
+// GetLocationAreasContext is like GetLocationAreas but abandons the request
+// once ctx is done.
+func (c *Client) GetLocationAreasContext(ctx context.Context, url string) (*LocationAreasResponse, error) {
+	data, err := c.fetchWithCache(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
 	var response LocationAreasResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := c.decode(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse location areas: %w", err)
 	}
+	response.Next = c.rebase(response.Next)
+	response.Previous = c.rebase(response.Previous)
 
 	return &response, nil
 }
 
 // GetLocationArea fetches details for a specific location area by name.
 func (c *Client) GetLocationArea(name string) (*LocationAreaResponse, error) {
+	return c.GetLocationAreaContext(context.Background(), name)
+}
+
+// GetLocationAreaContext is like GetLocationArea but abandons the request once
+// ctx is done.
+func (c *Client) GetLocationAreaContext(ctx context.Context, name string) (*LocationAreaResponse, error) {
 	url := fmt.Sprintf("%s/location-area/%s/", c.baseURL, name)
 
-	data, err := c.fetchWithCache(url)
+	data, err := c.fetchWithCache(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
 	var response LocationAreaResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := c.decode(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse location area: %w", err)
 	}
 
@@ -69,50 +209,325 @@ func (c *Client) GetFirstLocationAreasURL() string {
 	return fmt.Sprintf("%s/location-area/", c.baseURL)
 }
 
+// LocationAreasURL returns the URL for a page of location areas starting at
+// offset. The first page at DefaultPageSize uses the URL returned by
+// GetFirstLocationAreasURL, so both share a cache entry and the snapshot.
+func (c *Client) LocationAreasURL(offset, limit int) string {
+	if offset == 0 && limit == DefaultPageSize {
+		return c.GetFirstLocationAreasURL()
+	}
+	return fmt.Sprintf("%s/location-area/?offset=%d&limit=%d", c.baseURL, offset, limit)
+}
+
+// GetLocationAreaBatch fetches several location areas by name concurrently,
+// respecting the client's concurrency limit. Results are returned in the order
+// of names; entries that failed to load are nil and their errors are joined in
+// the returned error.
+func (c *Client) GetLocationAreaBatch(names []string) ([]*LocationAreaResponse, error) {
+	return c.GetLocationAreaBatchContext(context.Background(), names)
+}
+
+// GetLocationAreaBatchContext is like GetLocationAreaBatch but stops once ctx
+// is done: no new fetches start and requests in flight are abandoned. Areas
+// that were not fetched are nil, and the returned error then includes the
+// context's error.
+func (c *Client) GetLocationAreaBatchContext(ctx context.Context, names []string) ([]*LocationAreaResponse, error) {
+	return fetchAll(ctx, c, names, c.GetLocationAreaContext)
+}
+
 // GetPokemon fetches details for a specific Pokemon by name.
 func (c *Client) GetPokemon(name string) (*Pokemon, error) {
+	return c.GetPokemonContext(context.Background(), name)
+}
+
+// GetPokemonContext is like GetPokemon but abandons the request once ctx is
+// done.
+func (c *Client) GetPokemonContext(ctx context.Context, name string) (*Pokemon, error) {
 	url := fmt.Sprintf("%s/pokemon/%s/", c.baseURL, name)
 
-	data, err := c.fetchWithCache(url)
+	data, err := c.fetchWithCache(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
 	var response Pokemon
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := c.decode(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse pokemon: %w", err)
 	}
 
 	return &response, nil
 }
 
+// GetPokemonSpecies fetches species data for a Pokemon species by name.
+func (c *Client) GetPokemonSpecies(name string) (*PokemonSpecies, error) {
+	return c.GetPokemonSpeciesContext(context.Background(), name)
+}
+
+// GetPokemonSpeciesContext is like GetPokemonSpecies but abandons the request
+// once ctx is done.
+func (c *Client) GetPokemonSpeciesContext(ctx context.Context, name string) (*PokemonSpecies, error) {
+	url := fmt.Sprintf("%s/pokemon-species/%s/", c.baseURL, name)
+
+	data, err := c.fetchWithCache(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var response PokemonSpecies
+	if err := c.decode(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse pokemon species: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetEvolutionChain fetches an evolution chain from the given URL, as found in
+// PokemonSpecies.EvolutionChain.
+func (c *Client) GetEvolutionChain(url string) (*EvolutionChain, error) {
+	return c.GetEvolutionChainContext(context.Background(), url)
+}
+
+// GetEvolutionChainContext is like GetEvolutionChain but abandons the request
+// once ctx is done.
+func (c *Client) GetEvolutionChainContext(ctx context.Context, url string) (*EvolutionChain, error) {
+	data, err := c.fetchWithCache(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var response EvolutionChain
+	if err := c.decode(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse evolution chain: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetEvolutionLine fetches the Pokemon for every stage of the named species'
+// evolution family, ordered from the base form to the final evolutions and
+// including every branch. Each stage is fetched as the Pokemon named after its
+// species, concurrently and respecting the client's concurrency limit. As with
+// GetPokemonBatch, stages that failed to load are nil and their errors are
+// joined in the returned error.
+func (c *Client) GetEvolutionLine(name string) ([]*Pokemon, error) {
+	return c.GetEvolutionLineContext(context.Background(), name)
+}
+
+// GetEvolutionLineContext is like GetEvolutionLine but stops once ctx is done,
+// as GetPokemonBatchContext does.
+func (c *Client) GetEvolutionLineContext(ctx context.Context, name string) ([]*Pokemon, error) {
+	species, err := c.GetPokemonSpeciesContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if species.EvolutionChain.URL == "" {
+		return nil, fmt.Errorf("%s has no evolution chain", species.Name)
+	}
+
+	chain, err := c.GetEvolutionChainContext(ctx, species.EvolutionChain.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchAll(ctx, c, chain.Stages(), c.GetPokemonContext)
+}
+
+// GetPokemonByID fetches details for a specific Pokemon by National Pokedex number.
+func (c *Client) GetPokemonByID(id int) (*Pokemon, error) {
+	return c.GetPokemonByIDContext(context.Background(), id)
+}
+
+// GetPokemonByIDContext is like GetPokemonByID but abandons the request once
+// ctx is done.
+func (c *Client) GetPokemonByIDContext(ctx context.Context, id int) (*Pokemon, error) {
+	return c.GetPokemonContext(ctx, strconv.Itoa(id))
+}
+
+// GetPokemonByIDs fetches several Pokemon by National Pokedex number
+// concurrently, respecting the client's concurrency limit. As with
+// GetPokemonBatch, results are in the order of ids and failed entries are nil.
+func (c *Client) GetPokemonByIDs(ids []int) ([]*Pokemon, error) {
+	return c.GetPokemonByIDsContext(context.Background(), ids)
+}
+
+// GetPokemonByIDsContext is like GetPokemonByIDs but stops once ctx is done,
+// as GetPokemonBatchContext does.
+func (c *Client) GetPokemonByIDsContext(ctx context.Context, ids []int) ([]*Pokemon, error) {
+	return fetchAll(ctx, c, ids, c.GetPokemonByIDContext)
+}
+
+// GetPokemonBatch fetches several Pokemon by name concurrently, respecting the
+// client's concurrency limit. Results are returned in the order of names;
+// entries that failed to load are nil and their errors are joined in the
+// returned error.
+func (c *Client) GetPokemonBatch(names []string) ([]*Pokemon, error) {
+	return c.GetPokemonBatchContext(context.Background(), names)
+}
+
+// GetPokemonBatchContext is like GetPokemonBatch but stops once ctx is done:
+// no new fetches start and requests in flight are abandoned. Pokemon that
+// were not fetched are nil, and the returned error then includes the
+// context's error.
+func (c *Client) GetPokemonBatchContext(ctx context.Context, names []string) ([]*Pokemon, error) {
+	return fetchAll(ctx, c, names, c.GetPokemonContext)
+}
+
+// GetMove fetches details for a specific move by name.
+func (c *Client) GetMove(name string) (*Move, error) {
+	return c.GetMoveContext(context.Background(), name)
+}
+
+// GetMoveContext is like GetMove but abandons the request once ctx is done.
+func (c *Client) GetMoveContext(ctx context.Context, name string) (*Move, error) {
+	url := fmt.Sprintf("%s/move/%s/", c.baseURL, name)
+
+	data, err := c.fetchWithCache(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var response Move
+	if err := c.decode(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse move: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetMoves fetches several moves concurrently, respecting the client's
+// concurrency limit. Results are returned in the order of names; entries that
+// failed to load are nil and their errors are joined in the returned error.
+func (c *Client) GetMoves(names []string) ([]*Move, error) {
+	return c.GetMovesContext(context.Background(), names)
+}
+
+// GetMovesContext is like GetMoves but stops once ctx is done, as
+// GetPokemonBatchContext does.
+func (c *Client) GetMovesContext(ctx context.Context, names []string) ([]*Move, error) {
+	return fetchAll(ctx, c, names, c.GetMoveContext)
+}
+
+// GetPokemonList fetches the names of every Pokemon known to the API.
+func (c *Client) GetPokemonList() (*PokemonListResponse, error) {
+	return c.GetPokemonListContext(context.Background())
+}
+
+// GetPokemonListContext is like GetPokemonList but abandons the request once
+// ctx is done.
+func (c *Client) GetPokemonListContext(ctx context.Context) (*PokemonListResponse, error) {
+	url := fmt.Sprintf("%s/pokemon/?limit=%d", c.baseURL, pokemonListLimit)
+
+	data, err := c.fetchWithCache(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var response PokemonListResponse
+	if err := c.decode(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse pokemon list: %w", err)
+	}
+
+	return &response, nil
+}
+
+// decode parses a JSON response into v. In strict mode, unknown fields are
+// rejected for types that declare their endpoint's full schema.
+func (c *Client) decode(data []byte, v any) error {
+	if _, complete := v.(fullSchema); !c.strictJSON || !complete {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
 // fetchWithCache retrieves data from the cache or fetches from the API.
-// Returns whether the data was retrieved from cache.
-func (c *Client) fetchWithCache(url string) ([]byte, error) {
-	// Check cache first
-	if data, ok := c.cache.Get(url); ok {
-		fmt.Println("(using cached data)")
+// Concurrent fetches of the same URL are coalesced into a single request,
+// which is abandoned when the ctx of the caller that started it is done.
+// Cache hits and fetches are reported to the client's Metrics; coalesced
+// callers are counted in Stats but not reported separately.
+func (c *Client) fetchWithCache(ctx context.Context, url string) ([]byte, error) {
+	start := time.Now()
+
+	// Check cache first, unless a fresh response was requested
+	if data, ok := c.cache.Get(url); ok && !c.fresh {
+		fmt.Fprintln(c.notices, "(using cached data)")
+		c.counters.hits.Add(1)
+		c.metrics.ObserveRequest(url, http.StatusOK, time.Since(start), true)
+		return data, nil
+	}
+
+	data, err, shared := c.flights.do(url, func() ([]byte, error) {
+		// Fetch from API
+		data, status, err := c.fetch(ctx, url)
+		c.metrics.ObserveRequest(url, status, time.Since(start), false)
+		// Only requests that got a response are sampled; offline misses and
+		// failures before a response would skew the percentiles.
+		if status != 0 {
+			c.latencies.record(c.endpointOf(url), time.Since(start))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Store in cache
+		c.cache.AddWithTTL(url, data, c.ttlFor(url))
 		return data, nil
+	})
+	if shared {
+		c.counters.coalesced.Add(1)
+	} else {
+		c.counters.misses.Add(1)
+	}
+
+	return data, err
+}
+
+// fetch performs an HTTP GET for url and returns the body and status code.
+// Responses are requested gzip-compressed and decompressed transparently, and
+// bodies that are not JSON are rejected with ErrNonJSON. The request is
+// abandoned when ctx is done. The status is 0 if no response was received.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, int, error) {
+	if c.offline {
+		return nil, 0, fmt.Errorf("%w: %s not in cache", ErrOffline, url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
+	// Setting the header ourselves disables the transport's transparent
+	// decompression, so gzip bodies are decoded below.
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	// Fetch from API
-	resp, err := http.Get(url)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
+		return nil, 0, fmt.Errorf("%s: %w", describeFetchError(err), err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, resp.StatusCode, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body := io.Reader(resp.Body)
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("failed to decompress response: %w", err)
+		}
+		defer gz.Close()
+		body = gz
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	data, err := io.ReadAll(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Store in cache
-	c.cache.Add(url, data)
+	if err := checkJSON(resp.StatusCode, resp.Header.Get("Content-Type"), data); err != nil {
+		return nil, resp.StatusCode, err
+	}
 
-	return data, nil
+	return data, resp.StatusCode, nil
 }
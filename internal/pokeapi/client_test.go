@@ -0,0 +1,288 @@
+package pokeapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingTransport records every outgoing request URL and answers with a fixed body.
+type recordingTransport struct {
+	mu   sync.Mutex
+	urls []string
+	body string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.urls = append(rt.urls, req.URL.String())
+	rt.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(rt.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestWithTransportRecordsRequests(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu", "base_experience": 112}`}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+	if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 {
+		t.Errorf("unexpected pokemon: %+v", pokemon)
+	}
+
+	if _, err := client.GetLocationArea("canalave-city-area"); err != nil {
+		t.Fatalf("GetLocationArea failed: %v", err)
+	}
+
+	// A cached request must not reach the transport again.
+	if _, err := client.GetPokemon("pikachu"); err != nil {
+		t.Fatalf("cached GetPokemon failed: %v", err)
+	}
+
+	expected := []string{
+		BaseURL + "/pokemon/pikachu/",
+		BaseURL + "/location-area/canalave-city-area/",
+	}
+	if len(rt.urls) != len(expected) {
+		t.Fatalf("expected %d requests, got %d: %v", len(expected), len(rt.urls), rt.urls)
+	}
+	for i, url := range expected {
+		if rt.urls[i] != url {
+			t.Errorf("request %d: expected %q, got %q", i, url, rt.urls[i])
+		}
+	}
+}
+
+// recordingMetrics collects every observation passed to ObserveRequest.
+type recordingMetrics struct {
+	observations []observation
+}
+
+type observation struct {
+	url      string
+	status   int
+	cacheHit bool
+}
+
+func (m *recordingMetrics) ObserveRequest(url string, status int, d time.Duration, cacheHit bool) {
+	m.observations = append(m.observations, observation{url: url, status: status, cacheHit: cacheHit})
+}
+
+func TestWithMetricsObservesRequests(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu"}`}
+	metrics := &recordingMetrics{}
+	client := NewClient(WithTransport(rt), WithMetrics(metrics), WithoutSnapshot())
+
+	for range 2 {
+		if _, err := client.GetPokemon("pikachu"); err != nil {
+			t.Fatalf("GetPokemon failed: %v", err)
+		}
+	}
+
+	url := BaseURL + "/pokemon/pikachu/"
+	expected := []observation{
+		{url: url, status: http.StatusOK, cacheHit: false},
+		{url: url, status: http.StatusOK, cacheHit: true},
+	}
+	if len(metrics.observations) != len(expected) {
+		t.Fatalf("expected %d observations, got %d", len(expected), len(metrics.observations))
+	}
+	for i, want := range expected {
+		if metrics.observations[i] != want {
+			t.Errorf("observation %d: expected %+v, got %+v", i, want, metrics.observations[i])
+		}
+	}
+}
+
+func TestWithMetricsNil(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu"}`}
+	client := NewClient(WithTransport(rt), WithMetrics(nil), WithoutSnapshot())
+
+	if _, err := client.GetPokemon("pikachu"); err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+}
+
+func TestWithOfflineMiss(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu"}`}
+	client := NewClient(WithTransport(rt), WithOffline(), WithoutSnapshot())
+
+	_, err := client.GetPokemon("pikachu")
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected ErrOffline, got %v", err)
+	}
+
+	expected := "offline: " + BaseURL + "/pokemon/pikachu/ not in cache"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+
+	if len(rt.urls) != 0 {
+		t.Errorf("expected no network requests, got %v", rt.urls)
+	}
+}
+
+func TestWithOfflineHit(t *testing.T) {
+	client := NewClient(WithOffline(), WithoutSnapshot())
+	url := BaseURL + "/pokemon/pikachu/"
+	client.Cache().Add(url, []byte(`{"name": "pikachu"}`))
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("expected cached response, got error: %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %q", pokemon.Name)
+	}
+}
+
+func TestFetchDecompressesGzip(t *testing.T) {
+	const body = `{"count": 1, "next": null, "previous": null, "results": [{"name": "canalave-city-area", "url": ""}]}`
+
+	var acceptEncoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		io.WriteString(gz, body)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	url := srv.URL + "/location-area/"
+
+	resp, err := client.GetLocationAreas(url)
+	if err != nil {
+		t.Fatalf("GetLocationAreas failed: %v", err)
+	}
+
+	if acceptEncoding != "gzip" {
+		t.Errorf("expected Accept-Encoding gzip, got %q", acceptEncoding)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Name != "canalave-city-area" {
+		t.Errorf("unexpected results: %+v", resp.Results)
+	}
+
+	cached, ok := client.Cache().Get(url)
+	if !ok || string(cached) != body {
+		t.Errorf("expected decompressed body to be cached, got %q", cached)
+	}
+}
+
+func TestWithStrictJSON(t *testing.T) {
+	const body = `{"name": "pikachu", "base_experience": 112, "brand_new_field": true}`
+
+	lenient := NewClient(WithTransport(&recordingTransport{body: body}), WithoutSnapshot())
+	pokemon, err := lenient.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("expected lenient mode to ignore unknown fields, got %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %q", pokemon.Name)
+	}
+
+	strict := NewClient(WithTransport(&recordingTransport{body: body}), WithoutSnapshot(), WithStrictJSON())
+	_, err = strict.GetPokemon("pikachu")
+	if err == nil || !strings.Contains(err.Error(), "brand_new_field") {
+		t.Errorf("expected strict mode to reject the unknown field, got %v", err)
+	}
+
+	// Partially declared types are decoded leniently even in strict mode.
+	const moveBody = `{"name": "thunderbolt", "effect_entries": [], "generation": {"name": "generation-i"}}`
+	strict = NewClient(WithTransport(&recordingTransport{body: moveBody}), WithoutSnapshot(), WithStrictJSON())
+	if _, err := strict.GetMove("thunderbolt"); err != nil {
+		t.Errorf("expected strict mode to accept a move with undeclared fields, got %v", err)
+	}
+}
+
+func TestFreshBypassesCacheRead(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu"}`}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	if _, err := client.GetPokemon("pikachu"); err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+
+	fresh := client.Fresh()
+	for range 2 {
+		if _, err := fresh.GetPokemon("pikachu"); err != nil {
+			t.Fatalf("fresh GetPokemon failed: %v", err)
+		}
+	}
+	if len(rt.urls) != 3 {
+		t.Errorf("expected every fresh call to reach the server, got %d requests", len(rt.urls))
+	}
+
+	// Fresh responses still populate the shared cache.
+	rt.body = `{"name": "raichu"}`
+	if _, err := fresh.GetPokemon("raichu"); err != nil {
+		t.Fatalf("fresh GetPokemon failed: %v", err)
+	}
+	if _, err := client.GetPokemon("raichu"); err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+	if len(rt.urls) != 4 {
+		t.Errorf("expected the cached fresh response to be reused, got %d requests", len(rt.urls))
+	}
+}
+
+func TestWithNoticesRedirectsCacheNotice(t *testing.T) {
+	var notices bytes.Buffer
+	client := NewClient(WithNotices(&notices), WithOffline())
+
+	// The first location page is part of the embedded snapshot, so this is a cache hit.
+	if _, err := client.GetLocationAreas(client.GetFirstLocationAreasURL()); err != nil {
+		t.Fatalf("GetLocationAreas failed: %v", err)
+	}
+	if notices.String() != "(using cached data)\n" {
+		t.Errorf("expected the cache notice, got %q", notices.String())
+	}
+}
+
+func TestHTMLResponseIsRejected(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, "<html><body>Please log in to continue</body></html>")
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL), WithoutSnapshot())
+
+	for range 2 {
+		_, err := client.GetPokemon("pikachu")
+		if !errors.Is(err, ErrNonJSON) {
+			t.Fatalf("expected ErrNonJSON, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "status 200") || !strings.Contains(err.Error(), "text/html") {
+			t.Errorf("expected the status and content type in the error, got %q", err)
+		}
+	}
+
+	// The HTML body must not have been cached.
+	if requests != 2 {
+		t.Errorf("expected every call to reach the server, got %d requests", requests)
+	}
+	if client.Cache().Len() != 0 {
+		t.Errorf("expected an empty cache, got %d entries", client.Cache().Len())
+	}
+}
@@ -0,0 +1,51 @@
+package pokeapi
+
+// EncounterSummary is a flattened view of one encounter slot: how a Pokemon
+// can be encountered in a location area with one method in one game version.
+type EncounterSummary struct {
+	PokemonName string
+	Version     string
+	Method      string
+	MinLevel    int
+	MaxLevel    int
+
+	// Chance is the chance of this slot.
+	Chance int
+
+	// MaxChance is the highest chance of encountering the Pokemon in this
+	// version by any method, as reported by the API.
+	MaxChance int
+
+	// Conditions lists the encounter condition values (such as "time-night")
+	// that must hold, in API order; it is empty when there are none.
+	Conditions []string
+}
+
+// SummarizeEncounters flattens the nested encounter details of resp into one
+// summary per encounter slot. Summaries keep the order in which the API lists
+// Pokemon, versions, and slots.
+func SummarizeEncounters(resp *LocationAreaResponse) []EncounterSummary {
+	var summaries []EncounterSummary
+	for _, encounter := range resp.PokemonEncounters {
+		for _, version := range encounter.VersionDetails {
+			for _, detail := range version.EncounterDetails {
+				conditions := make([]string, len(detail.ConditionValues))
+				for i, cv := range detail.ConditionValues {
+					conditions[i] = cv.Name
+				}
+
+				summaries = append(summaries, EncounterSummary{
+					PokemonName: encounter.Pokemon.Name,
+					Version:     version.Version.Name,
+					Method:      detail.Method.Name,
+					MinLevel:    detail.MinLevel,
+					MaxLevel:    detail.MaxLevel,
+					Chance:      detail.Chance,
+					MaxChance:   version.MaxChance,
+					Conditions:  conditions,
+				})
+			}
+		}
+	}
+	return summaries
+}
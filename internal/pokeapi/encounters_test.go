@@ -0,0 +1,44 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeEncounters(t *testing.T) {
+	data, err := os.ReadFile("testdata/location_area_multi.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var area LocationAreaResponse
+	if err := json.Unmarshal(data, &area); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	got := SummarizeEncounters(&area)
+
+	expected := []EncounterSummary{
+		// Every slot is kept, even when slots share a method and version.
+		{PokemonName: "zubat", Version: "diamond", Method: "walk", MinLevel: 12, MaxLevel: 12, Chance: 20, MaxChance: 50, Conditions: []string{}},
+		{PokemonName: "zubat", Version: "diamond", Method: "walk", MinLevel: 14, MaxLevel: 15, Chance: 10, MaxChance: 50, Conditions: []string{}},
+		{PokemonName: "zubat", Version: "diamond", Method: "walk", MinLevel: 13, MaxLevel: 13, Chance: 10, MaxChance: 50, Conditions: []string{"time-night"}},
+		{PokemonName: "zubat", Version: "diamond", Method: "rock-smash", MinLevel: 10, MaxLevel: 20, Chance: 10, MaxChance: 50, Conditions: []string{}},
+		{PokemonName: "zubat", Version: "pearl", Method: "walk", MinLevel: 11, MaxLevel: 13, Chance: 30, MaxChance: 30, Conditions: []string{}},
+		// Conditions keep the order the API lists them in.
+		{PokemonName: "geodude", Version: "platinum", Method: "rock-smash", MinLevel: 5, MaxLevel: 10, Chance: 60, MaxChance: 100, Conditions: []string{"swarm-no", "radar-off"}},
+		{PokemonName: "geodude", Version: "platinum", Method: "rock-smash", MinLevel: 8, MaxLevel: 12, Chance: 60, MaxChance: 100, Conditions: []string{"radar-off", "swarm-no"}},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected summaries:\ngot:  %+v\nwant: %+v", got, expected)
+	}
+}
+
+func TestSummarizeEncountersEmpty(t *testing.T) {
+	got := SummarizeEncounters(&LocationAreaResponse{})
+	if len(got) != 0 {
+		t.Errorf("expected no summaries, got %+v", got)
+	}
+}
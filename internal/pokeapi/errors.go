@@ -0,0 +1,43 @@
+package pokeapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrNonJSON is returned when a response body is not JSON, which usually
+// means a proxy or captive portal answered instead of the API.
+var ErrNonJSON = errors.New("received a non-JSON response (possibly a proxy/login page)")
+
+// describeFetchError turns a low-level network error into an actionable message.
+func describeFetchError(err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return fmt.Sprintf("could not resolve %s (check your internet/DNS)", dnsErr.Name)
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return "connection refused"
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "request timed out"
+	}
+
+	return "failed to fetch data"
+}
+
+// checkJSON returns an error wrapping ErrNonJSON unless data looks like a JSON
+// object or array and contentType, if set, is not HTML.
+func checkJSON(status int, contentType string, data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && !strings.Contains(contentType, "html") {
+		return nil
+	}
+	return fmt.Errorf("%w (status %d, content type %q)", ErrNonJSON, status, contentType)
+}
@@ -0,0 +1,84 @@
+package pokeapi
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+)
+
+// timeoutError is a net.Error that always reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDescribeFetchError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: BaseURL + "/pokemon/pikachu/", Err: err}
+	}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "DNS failure",
+			err:      wrap(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "pokeapi.co", IsNotFound: true}}),
+			expected: "could not resolve pokeapi.co (check your internet/DNS)",
+		},
+		{
+			name:     "connection refused",
+			err:      wrap(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
+			expected: "connection refused",
+		},
+		{
+			name:     "timeout",
+			err:      wrap(timeoutError{}),
+			expected: "request timed out",
+		},
+		{
+			name:     "anything else",
+			err:      wrap(errors.New("unexpected EOF")),
+			expected: "failed to fetch data",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := describeFetchError(tc.err); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckJSON(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+		ok          bool
+	}{
+		{name: "object", contentType: "application/json", body: `{"name": "pikachu"}`, ok: true},
+		{name: "array with whitespace", body: "\n  [1, 2]", ok: true},
+		{name: "html page", contentType: "text/html", body: "<!DOCTYPE html><html></html>", ok: false},
+		{name: "json labelled as html", contentType: "text/html; charset=utf-8", body: `{}`, ok: false},
+		{name: "plain text", body: "Access denied", ok: false},
+		{name: "empty", body: "", ok: false},
+	}
+
+	for _, tc := range testCases {
+		err := checkJSON(200, tc.contentType, []byte(tc.body))
+		if tc.ok && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.ok && !errors.Is(err, ErrNonJSON) {
+			t.Errorf("%s: expected ErrNonJSON, got %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package pokeapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fixtureTransport serves species and evolution chains from testdata files
+// and answers Pokemon requests with a minimal body naming the Pokemon.
+type fixtureTransport struct {
+	// files maps request paths to testdata files.
+	files map[string]string
+}
+
+func (rt *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, ""
+	switch file, ok := rt.files[req.URL.Path]; {
+	case ok:
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	case strings.HasPrefix(req.URL.Path, "/api/v2/pokemon/"):
+		body = fmt.Sprintf(`{"name": %q}`, path.Base(req.URL.Path))
+	default:
+		status, body = http.StatusNotFound, "Not Found"
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestGetEvolutionLine(t *testing.T) {
+	rt := &fixtureTransport{files: map[string]string{
+		"/api/v2/pokemon-species/charmander/": "testdata/species-charmander.json",
+		"/api/v2/evolution-chain/2/":          "testdata/evolution-chain-charmander.json",
+		"/api/v2/pokemon-species/eevee/":      "testdata/species-eevee.json",
+		"/api/v2/evolution-chain/67/":         "testdata/evolution-chain-eevee.json",
+	}}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	testCases := []struct {
+		name     string
+		expected []string
+	}{
+		{
+			name:     "charmander",
+			expected: []string{"charmander", "charmeleon", "charizard"},
+		},
+		{
+			name: "eevee",
+			expected: []string{
+				"eevee",
+				"vaporeon", "jolteon", "flareon", "espeon",
+				"umbreon", "leafeon", "glaceon", "sylveon",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, err := client.GetEvolutionLine(tc.name)
+			if err != nil {
+				t.Fatalf("GetEvolutionLine failed: %v", err)
+			}
+
+			names := make([]string, len(line))
+			for i, p := range line {
+				names[i] = p.Name
+			}
+			if !slices.Equal(names, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+
+	if _, err := client.GetEvolutionLine("missingno"); err == nil {
+		t.Error("expected an error for an unknown species")
+	}
+}
@@ -0,0 +1,70 @@
+package pokeapi
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// nameIndex maps Pokemon names to National Pokedex numbers. It is built from
+// the full Pokemon list and rebuilt once it is older than the cache TTL. It is
+// not an LRU and never evicts: every name in the list is kept, so its size is
+// bounded by the list, which holds a few thousand names at most.
+type nameIndex struct {
+	mu      sync.Mutex
+	ids     map[string]int
+	builtAt time.Time
+}
+
+// ResolveName returns the National Pokedex number for a Pokemon name. The
+// index is built from the full Pokemon list on first use and whenever it has
+// expired; lookups in between never touch the network. It reports false if
+// the name is unknown or the list cannot be fetched.
+//
+// The list is fetched without holding the index lock, so a slow fetch never
+// blocks lookups; concurrent rebuilds share a single request.
+func (c *Client) ResolveName(name string) (int, bool) {
+	c.index.mu.Lock()
+	ids, builtAt := c.index.ids, c.index.builtAt
+	c.index.mu.Unlock()
+
+	if ids == nil || time.Since(builtAt) > c.cacheTTL {
+		list, err := c.GetPokemonList()
+		if err != nil {
+			return 0, false
+		}
+		ids = buildNameIndex(list.Results)
+
+		c.index.mu.Lock()
+		c.index.ids = ids
+		c.index.builtAt = time.Now()
+		c.index.mu.Unlock()
+	}
+
+	id, ok := ids[name]
+	return id, ok
+}
+
+// buildNameIndex maps each resource name to the ID at the end of its URL.
+// Entries without a numeric ID are skipped.
+func buildNameIndex(results []NamedResource) map[string]int {
+	ids := make(map[string]int, len(results))
+	for _, r := range results {
+		if id, ok := idFromURL(r.URL); ok {
+			ids[r.Name] = id
+		}
+	}
+	return ids
+}
+
+// idFromURL extracts the trailing numeric ID from a resource URL such as
+// "https://pokeapi.co/api/v2/pokemon/25/".
+func idFromURL(url string) (int, bool) {
+	trimmed := strings.TrimSuffix(url, "/")
+	id, err := strconv.Atoi(trimmed[strings.LastIndex(trimmed, "/")+1:])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
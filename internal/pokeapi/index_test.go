@@ -0,0 +1,118 @@
+package pokeapi
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const pokemonListFixture = `{
+	"count": 4,
+	"next": null,
+	"previous": null,
+	"results": [
+		{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"},
+		{"name": "charmander", "url": "https://pokeapi.co/api/v2/pokemon/4/"},
+		{"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon/25/"},
+		{"name": "calyrex-shadow", "url": "https://pokeapi.co/api/v2/pokemon/10194/"}
+	]
+}`
+
+func TestResolveName(t *testing.T) {
+	rt := &recordingTransport{body: pokemonListFixture}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	expected := map[string]int{
+		"bulbasaur":      1,
+		"charmander":     4,
+		"pikachu":        25,
+		"calyrex-shadow": 10194,
+	}
+	for name, want := range expected {
+		id, ok := client.ResolveName(name)
+		if !ok || id != want {
+			t.Errorf("ResolveName(%q): expected %d, got %d (found: %v)", name, want, id, ok)
+		}
+	}
+
+	if _, ok := client.ResolveName("missingno"); ok {
+		t.Error("expected unknown names not to resolve")
+	}
+
+	if len(rt.urls) != 1 {
+		t.Errorf("expected the list to be fetched once, got %d requests", len(rt.urls))
+	}
+
+	// An expired index is rebuilt on the next lookup.
+	expired := time.Now().Add(-2 * DefaultCacheTTL)
+	client.index.builtAt = expired
+	if _, ok := client.ResolveName("pikachu"); !ok {
+		t.Error("expected pikachu to resolve after a rebuild")
+	}
+	if !client.index.builtAt.After(expired) {
+		t.Error("expected the expired index to be rebuilt")
+	}
+}
+
+func TestResolveNameDoesNotBlockDuringFetch(t *testing.T) {
+	rt := &blockingListTransport{release: make(chan struct{}), started: make(chan struct{}, 1)}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+	client.index.ids = map[string]int{"pikachu": 25}
+	client.index.builtAt = time.Now().Add(-2 * DefaultCacheTTL)
+
+	// The first lookup starts rebuilding the expired index and blocks in the fetch.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.ResolveName("pikachu")
+	}()
+	<-rt.started
+
+	// The index lock must not be held while the list is fetched.
+	if !client.index.mu.TryLock() {
+		t.Error("expected the index lock to be free during the fetch")
+	} else {
+		client.index.mu.Unlock()
+	}
+
+	close(rt.release)
+	<-done
+}
+
+// blockingListTransport serves the Pokemon list fixture once release is closed.
+type blockingListTransport struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (rt *blockingListTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.started <- struct{}{}
+	<-rt.release
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(pokemonListFixture)),
+		Request:    req,
+	}, nil
+}
+
+func TestIDFromURL(t *testing.T) {
+	testCases := []struct {
+		url string
+		id  int
+		ok  bool
+	}{
+		{url: "https://pokeapi.co/api/v2/pokemon/25/", id: 25, ok: true},
+		{url: "https://pokeapi.co/api/v2/pokemon/133", id: 133, ok: true},
+		{url: "https://pokeapi.co/api/v2/pokemon/pikachu/", ok: false},
+		{url: "", ok: false},
+	}
+
+	for _, tc := range testCases {
+		id, ok := idFromURL(tc.url)
+		if id != tc.id || ok != tc.ok {
+			t.Errorf("idFromURL(%q): expected (%d, %v), got (%d, %v)", tc.url, tc.id, tc.ok, id, ok)
+		}
+	}
+}
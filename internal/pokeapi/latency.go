@@ -0,0 +1,112 @@
+package pokeapi
+
+import (
+	"cmp"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLatencySamples is how many of the most recent fetch durations are kept
+// per endpoint; older samples are overwritten.
+const maxLatencySamples = 256
+
+// LatencySummary describes the fetch durations recorded for one endpoint.
+type LatencySummary struct {
+	// Endpoint is the API endpoint, such as "pokemon" or "location-area".
+	Endpoint string
+	// Count is the number of samples the summary is based on.
+	Count int
+	P50   time.Duration
+	P95   time.Duration
+	Max   time.Duration
+}
+
+// latencyRecorder keeps a bounded ring of recent fetch durations per endpoint.
+type latencyRecorder struct {
+	mu      sync.Mutex
+	samples map[string]*latencyRing
+}
+
+// latencyRing is a fixed-size ring buffer of durations.
+type latencyRing struct {
+	buf  []time.Duration
+	next int
+}
+
+// record adds a fetch duration for endpoint, overwriting the oldest sample
+// once the ring is full.
+func (r *latencyRecorder) record(endpoint string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.samples == nil {
+		r.samples = make(map[string]*latencyRing)
+	}
+	ring, ok := r.samples[endpoint]
+	if !ok {
+		ring = &latencyRing{}
+		r.samples[endpoint] = ring
+	}
+
+	if len(ring.buf) < maxLatencySamples {
+		ring.buf = append(ring.buf, d)
+		return
+	}
+	ring.buf[ring.next] = d
+	ring.next = (ring.next + 1) % maxLatencySamples
+}
+
+// summaries returns a summary per endpoint, sorted by endpoint name.
+func (r *latencyRecorder) summaries() []LatencySummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]LatencySummary, 0, len(r.samples))
+	for endpoint, ring := range r.samples {
+		sorted := slices.Clone(ring.buf)
+		slices.Sort(sorted)
+		result = append(result, LatencySummary{
+			Endpoint: endpoint,
+			Count:    len(sorted),
+			P50:      percentile(sorted, 0.50),
+			P95:      percentile(sorted, 0.95),
+			Max:      sorted[len(sorted)-1],
+		})
+	}
+	slices.SortFunc(result, func(a, b LatencySummary) int {
+		return cmp.Compare(a.Endpoint, b.Endpoint)
+	})
+	return result
+}
+
+// percentile returns the nearest-rank p-th percentile (0 < p <= 1) of sorted,
+// which must be non-empty and in ascending order.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// endpointOf returns the API endpoint a request URL belongs to: the first
+// path segment after the client's base URL, such as "pokemon".
+func (c *Client) endpointOf(rawURL string) string {
+	path := strings.TrimPrefix(rawURL, c.baseURL)
+	if path == rawURL {
+		if u, err := url.Parse(rawURL); err == nil {
+			path = u.Path
+		}
+	}
+	path = strings.TrimLeft(path, "/")
+	path, _, _ = strings.Cut(path, "?")
+	endpoint, _, _ := strings.Cut(path, "/")
+	return endpoint
+}
+
+// Latencies summarizes the durations of requests sent to the API, per
+// endpoint. Cache hits are not included.
+func (c *Client) Latencies() []LatencySummary {
+	return c.latencies.summaries()
+}
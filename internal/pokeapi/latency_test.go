@@ -0,0 +1,104 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLatencyPercentiles(t *testing.T) {
+	var r latencyRecorder
+	for i := 1; i <= 100; i++ {
+		r.record("pokemon", time.Duration(i)*time.Millisecond)
+	}
+	r.record("location-area", 7*time.Millisecond)
+
+	expected := []LatencySummary{
+		{Endpoint: "location-area", Count: 1, P50: 7 * time.Millisecond, P95: 7 * time.Millisecond, Max: 7 * time.Millisecond},
+		{Endpoint: "pokemon", Count: 100, P50: 50 * time.Millisecond, P95: 95 * time.Millisecond, Max: 100 * time.Millisecond},
+	}
+
+	got := r.summaries()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d summaries, got %+v", len(expected), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], got[i])
+		}
+	}
+}
+
+func TestLatencyRecorderIsBounded(t *testing.T) {
+	var r latencyRecorder
+	for range maxLatencySamples {
+		r.record("move", time.Second)
+	}
+	// Newer samples replace the oldest ones once the ring is full.
+	for range maxLatencySamples {
+		r.record("move", time.Millisecond)
+	}
+
+	got := r.summaries()[0]
+	if got.Count != maxLatencySamples {
+		t.Errorf("expected %d samples, got %d", maxLatencySamples, got.Count)
+	}
+	if got.Max != time.Millisecond {
+		t.Errorf("expected the old samples to be overwritten, got max %s", got.Max)
+	}
+}
+
+func TestEndpointOf(t *testing.T) {
+	client := NewClient(WithoutSnapshot())
+
+	testCases := map[string]string{
+		BaseURL + "/pokemon/pikachu/":                      "pokemon",
+		BaseURL + "/location-area/":                        "location-area",
+		BaseURL + "/location-area/?offset=20&limit=20":     "location-area",
+		BaseURL + "/pokemon?limit=100000":                  "pokemon",
+		"http://127.0.0.1:8080/pokemon-species/bulbasaur/": "pokemon-species",
+	}
+	for url, expected := range testCases {
+		if got := client.endpointOf(url); got != expected {
+			t.Errorf("endpointOf(%q) = %q, expected %q", url, got, expected)
+		}
+	}
+}
+
+func TestFetchesRecordLatency(t *testing.T) {
+	rt := &recordingTransport{body: `{"name": "pikachu"}`}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	for range 2 {
+		if _, err := client.GetPokemon("pikachu"); err != nil {
+			t.Fatalf("GetPokemon failed: %v", err)
+		}
+	}
+
+	// Only the request sent to the API is sampled, not the cache hit.
+	got := client.Latencies()
+	if len(got) != 1 || got[0].Endpoint != "pokemon" || got[0].Count != 1 {
+		t.Errorf("expected one pokemon sample, got %+v", got)
+	}
+}
+
+func TestFailedFetchesRecordNoLatency(t *testing.T) {
+	offline := NewClient(WithOffline(), WithoutSnapshot())
+	if _, err := offline.GetPokemon("pikachu"); !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected ErrOffline, got %v", err)
+	}
+	if got := offline.Latencies(); len(got) != 0 {
+		t.Errorf("expected no samples for an offline miss, got %+v", got)
+	}
+
+	unreachable := NewClient(WithTransport(hangingTransport{}), WithoutSnapshot())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := unreachable.GetPokemonContext(ctx, "pikachu"); err == nil {
+		t.Fatal("expected an error for a cancelled request")
+	}
+	if got := unreachable.Latencies(); len(got) != 0 {
+		t.Errorf("expected no samples for a request without a response, got %+v", got)
+	}
+}
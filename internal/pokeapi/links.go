@@ -0,0 +1,58 @@
+package pokeapi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WithBaseURL points the client at a PokeAPI mirror instead of BaseURL.
+// Pagination links in responses are rewritten to the mirror's scheme, host
+// and base path so that following them stays on the mirror.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// EndpointURL returns the URL of an API endpoint, such as "pokemon" or
+// "/location-area/", under the client's base URL. It is useful as a cache key
+// prefix.
+func (c *Client) EndpointURL(endpoint string) string {
+	return c.baseURL + "/" + strings.Trim(endpoint, "/") + "/"
+}
+
+// rebase rewrites the scheme and host of link to match the client's base URL,
+// and replaces the upstream base path (/api/v2) with the base URL's path.
+// Links that cannot be parsed are returned unchanged.
+func (c *Client) rebase(link *string) *string {
+	if link == nil {
+		return nil
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return link
+	}
+	u, err := url.Parse(*link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+
+	u.Scheme = base.Scheme
+	u.Host = base.Host
+	if rest, ok := strings.CutPrefix(u.Path, upstreamPath()); ok {
+		u.Path = strings.TrimSuffix(base.Path, "/") + rest
+		u.RawPath = ""
+	}
+	rebased := u.String()
+	return &rebased
+}
+
+// upstreamPath returns the path of BaseURL, under which upstream links live.
+func upstreamPath() string {
+	u, err := url.Parse(BaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
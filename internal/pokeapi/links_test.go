@@ -0,0 +1,75 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginationLinksAreRebased(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"count": 60,
+			"next": "http://pokeapi.co/api/v2/location-area/?offset=40&limit=20",
+			"previous": "https://upstream.example:8443/api/v2/location-area/?offset=0&limit=20",
+			"results": []
+		}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL+"/api/v2/"), WithoutSnapshot())
+
+	resp, err := client.GetLocationAreas(client.LocationAreasURL(20, 20))
+	if err != nil {
+		t.Fatalf("GetLocationAreas failed: %v", err)
+	}
+
+	expectedNext := srv.URL + "/api/v2/location-area/?offset=40&limit=20"
+	if resp.Next == nil || *resp.Next != expectedNext {
+		t.Errorf("expected next %q, got %v", expectedNext, resp.Next)
+	}
+	expectedPrev := srv.URL + "/api/v2/location-area/?offset=0&limit=20"
+	if resp.Previous == nil || *resp.Previous != expectedPrev {
+		t.Errorf("expected previous %q, got %v", expectedPrev, resp.Previous)
+	}
+
+	// The rewritten link must be followable on the mirror.
+	if _, err := client.GetLocationAreas(*resp.Next); err != nil {
+		t.Errorf("following the rebased next link failed: %v", err)
+	}
+}
+
+func TestRebaseReplacesBasePath(t *testing.T) {
+	client := NewClient(WithBaseURL("https://mirror.example/pokeapi/api/v2/"), WithoutSnapshot())
+
+	link := "https://pokeapi.co/api/v2/location-area/?offset=40&limit=20"
+	expected := "https://mirror.example/pokeapi/api/v2/location-area/?offset=40&limit=20"
+	if got := client.rebase(&link); *got != expected {
+		t.Errorf("expected %q, got %q", expected, *got)
+	}
+}
+
+func TestRebaseLeavesRelativeAndMissingLinks(t *testing.T) {
+	client := NewClient(WithoutSnapshot())
+
+	if got := client.rebase(nil); got != nil {
+		t.Errorf("expected nil, got %q", *got)
+	}
+
+	relative := "/location-area/?offset=20"
+	if got := client.rebase(&relative); *got != relative {
+		t.Errorf("expected relative link to be unchanged, got %q", *got)
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	client := NewClient(WithBaseURL("http://mirror.example/api/v2/"), WithoutSnapshot())
+
+	for _, endpoint := range []string{"pokemon", "/pokemon/", "pokemon/"} {
+		if got := client.EndpointURL(endpoint); got != "http://mirror.example/api/v2/pokemon/" {
+			t.Errorf("EndpointURL(%q) = %q", endpoint, got)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package pokeapi
+
+import "time"
+
+// Metrics receives an observation for every request served by the Client.
+// Implementations can forward these to Prometheus or another metrics system.
+//
+// status is the HTTP status code of the response, or 0 if the request failed
+// before a response was received. Cache hits are reported with http.StatusOK.
+type Metrics interface {
+	ObserveRequest(url string, status int, d time.Duration, cacheHit bool)
+}
+
+// noopMetrics is the default Metrics implementation and discards all observations.
+type noopMetrics struct{}
+
+// ObserveRequest implements Metrics.
+func (noopMetrics) ObserveRequest(string, int, time.Duration, bool) {}
+
+// WithMetrics sets the Metrics implementation notified of every request.
+// A nil m discards all observations.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		if m == nil {
+			m = noopMetrics{}
+		}
+		c.metrics = m
+	}
+}
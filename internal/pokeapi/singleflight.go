@@ -0,0 +1,47 @@
+package pokeapi
+
+import "sync"
+
+// flightGroup deduplicates concurrent fetches of the same URL so that only one
+// request is sent while other callers wait for and share its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a fetch in progress.
+type flight struct {
+	wg      sync.WaitGroup
+	waiters int
+	data    []byte
+	err     error
+}
+
+// do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call instead. shared reports whether the result came
+// from another caller's call.
+func (g *flightGroup) do(key string, fn func() ([]byte, error)) (data []byte, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	if f, ok := g.calls[key]; ok {
+		f.waiters++
+		g.mu.Unlock()
+		f.wg.Wait()
+		return f.data, f.err, true
+	}
+	f := &flight{}
+	f.wg.Add(1)
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	f.data, f.err = fn()
+	f.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return f.data, f.err, false
+}
@@ -0,0 +1,72 @@
+package pokeapi
+
+import (
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// blockingTransport holds every request until release is closed.
+type blockingTransport struct {
+	requests atomic.Int32
+	release  chan struct{}
+}
+
+func (rt *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests.Add(1)
+	<-rt.release
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"name": "pikachu"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestConcurrentRequestsAreCoalesced(t *testing.T) {
+	rt := &blockingTransport{release: make(chan struct{})}
+	client := NewClient(WithTransport(rt), WithoutSnapshot())
+
+	const callers = 5
+	var done sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range callers {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			_, errs[i] = client.GetPokemon("pikachu")
+		}()
+	}
+
+	// Wait until every other caller has joined the single in-flight request.
+	for {
+		client.flights.mu.Lock()
+		f := client.flights.calls[BaseURL+"/pokemon/pikachu/"]
+		joined := f != nil && f.waiters == callers-1
+		client.flights.mu.Unlock()
+		if joined {
+			break
+		}
+		runtime.Gosched()
+	}
+	close(rt.release)
+	done.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("caller %d failed: %v", i, err)
+		}
+	}
+
+	if got := rt.requests.Load(); got != 1 {
+		t.Errorf("expected a single request, got %d", got)
+	}
+
+	expected := Stats{Misses: 1, Coalesced: callers - 1}
+	if stats := client.Stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
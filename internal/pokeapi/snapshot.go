@@ -0,0 +1,47 @@
+package pokeapi
+
+import (
+	"embed"
+	"time"
+)
+
+// SnapshotTTL is how long responses from the embedded snapshot stay cached.
+const SnapshotTTL = 24 * time.Hour
+
+// snapshotFS holds a small set of API responses bundled with the binary so
+// that common requests are answered instantly, even offline.
+//
+//go:embed snapshot/*.json
+var snapshotFS embed.FS
+
+// snapshotFiles maps API paths, relative to the base URL, to embedded files
+// holding complete responses. They are preloaded into the cache.
+var snapshotFiles = map[string]string{
+	"/location-area/":     "snapshot/location-area.json",
+	"/pokemon/pikachu/":   "snapshot/pokemon-pikachu.json",
+	"/pokemon/charizard/": "snapshot/pokemon-charizard.json",
+}
+
+// WithoutSnapshot disables preloading the cache with the embedded snapshot.
+func WithoutSnapshot() Option {
+	return func(c *Client) {
+		c.snapshot = false
+	}
+}
+
+// loadSnapshot adds every embedded response to the cache with SnapshotTTL.
+func (c *Client) loadSnapshot() {
+	for path, file := range snapshotFiles {
+		c.cache.AddWithTTL(c.baseURL+path, readSnapshotFile(file), SnapshotTTL)
+	}
+}
+
+// readSnapshotFile returns the contents of an embedded snapshot file.
+func readSnapshotFile(file string) []byte {
+	data, err := snapshotFS.ReadFile(file)
+	if err != nil {
+		// The file list is fixed at build time, so this cannot happen.
+		panic("pokeapi: missing snapshot file " + file)
+	}
+	return data
+}
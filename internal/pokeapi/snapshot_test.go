@@ -0,0 +1,66 @@
+package pokeapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSnapshotServesFirstLocationPage(t *testing.T) {
+	rt := &recordingTransport{body: `{}`}
+	client := NewClient(WithTransport(rt))
+
+	areas, err := client.GetLocationAreas(client.GetFirstLocationAreasURL())
+	if err != nil {
+		t.Fatalf("GetLocationAreas failed: %v", err)
+	}
+	if len(areas.Results) != 20 || areas.Next == nil {
+		t.Errorf("expected a full first page with a next link, got %d results", len(areas.Results))
+	}
+
+	if len(rt.urls) != 0 {
+		t.Errorf("expected no network requests, got %v", rt.urls)
+	}
+}
+
+func TestSnapshotServesPikachu(t *testing.T) {
+	rt := &recordingTransport{body: `{}`}
+	client := NewClient(WithTransport(rt))
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+	if pokemon.Name != "pikachu" || pokemon.ID != 25 || pokemon.BaseExperience == 0 || len(pokemon.Moves) == 0 {
+		t.Errorf("unexpected snapshot data: %+v", pokemon)
+	}
+
+	if len(rt.urls) != 0 {
+		t.Errorf("expected no network requests, got %v", rt.urls)
+	}
+}
+
+func TestSnapshotFilesParse(t *testing.T) {
+	for path, file := range snapshotFiles {
+		if _, err := snapshotFS.ReadFile(file); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+
+	// The bundled Pokemon are complete responses, so they pass strict decoding.
+	client := NewClient(WithOffline(), WithStrictJSON())
+	for _, name := range []string{"pikachu", "charizard"} {
+		if _, err := client.GetPokemon(name); err != nil {
+			t.Errorf("snapshot for %s does not parse: %v", name, err)
+		}
+	}
+}
+
+func TestWithoutSnapshot(t *testing.T) {
+	client := NewClient(WithoutSnapshot(), WithOffline())
+	if client.Cache().Len() != 0 {
+		t.Errorf("expected an empty cache, got %d entries", client.Cache().Len())
+	}
+	if _, err := client.GetPokemon("pikachu"); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline without the snapshot, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package pokeapi
+
+import "sync/atomic"
+
+// Stats summarizes how the client's requests have been served.
+type Stats struct {
+	// Hits counts requests answered from the cache.
+	Hits uint64
+	// Misses counts requests sent to the API.
+	Misses uint64
+	// Coalesced counts requests that joined an identical in-flight request
+	// instead of sending their own.
+	Coalesced uint64
+}
+
+// counters holds the live values behind Stats.
+type counters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+// Stats returns a snapshot of the client's request counters.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Hits:      c.counters.hits.Load(),
+		Misses:    c.counters.misses.Load(),
+		Coalesced: c.counters.coalesced.Load(),
+	}
+}
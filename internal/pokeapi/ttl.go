@@ -0,0 +1,41 @@
+package pokeapi
+
+import (
+	"maps"
+	"time"
+)
+
+// StaticDataTTL is the default time-to-live for endpoints whose data
+// practically never changes, such as types and abilities.
+const StaticDataTTL = 24 * time.Hour
+
+// defaultEndpointTTLs lists the endpoints cached longer than DefaultCacheTTL.
+var defaultEndpointTTLs = map[string]time.Duration{
+	"type":            StaticDataTTL,
+	"ability":         StaticDataTTL,
+	"move":            StaticDataTTL,
+	"pokemon-species": StaticDataTTL,
+	"evolution-chain": StaticDataTTL,
+}
+
+// WithEndpointTTL sets how long responses from an endpoint (such as "type" or
+// "pokemon") stay cached, overriding the default for that endpoint.
+func WithEndpointTTL(endpoint string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.endpointTTLs[endpoint] = ttl
+	}
+}
+
+// ttlFor returns the cache TTL for a request URL based on its endpoint, the
+// first path segment after the base URL.
+func (c *Client) ttlFor(url string) time.Duration {
+	if ttl, ok := c.endpointTTLs[c.endpointOf(url)]; ok {
+		return ttl
+	}
+	return c.cacheTTL
+}
+
+// newEndpointTTLs returns a copy of the default per-endpoint TTLs.
+func newEndpointTTLs() map[string]time.Duration {
+	return maps.Clone(defaultEndpointTTLs)
+}
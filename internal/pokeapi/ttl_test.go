@@ -0,0 +1,53 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLFor(t *testing.T) {
+	client := NewClient(WithoutSnapshot())
+
+	testCases := []struct {
+		url      string
+		expected time.Duration
+	}{
+		{url: BaseURL + "/type/electric/", expected: StaticDataTTL},
+		{url: BaseURL + "/ability/static/", expected: StaticDataTTL},
+		{url: BaseURL + "/pokemon-species/pikachu/", expected: StaticDataTTL},
+		{url: BaseURL + "/pokemon/pikachu/", expected: DefaultCacheTTL},
+		{url: BaseURL + "/location-area/?offset=20&limit=20", expected: DefaultCacheTTL},
+		// Only the first path segment names the endpoint.
+		{url: BaseURL + "/pokemon/?next=/type/fire/", expected: DefaultCacheTTL},
+		{url: BaseURL + "/pokemon/move/", expected: DefaultCacheTTL},
+	}
+
+	for _, tc := range testCases {
+		if got := client.ttlFor(tc.url); got != tc.expected {
+			t.Errorf("ttlFor(%q): expected %s, got %s", tc.url, tc.expected, got)
+		}
+	}
+}
+
+func TestWithEndpointTTL(t *testing.T) {
+	client := NewClient(
+		WithoutSnapshot(),
+		WithEndpointTTL("pokemon", time.Hour),
+		WithEndpointTTL("type", time.Minute),
+	)
+
+	if got := client.ttlFor(BaseURL + "/pokemon/pikachu/"); got != time.Hour {
+		t.Errorf("expected overridden pokemon TTL of 1h, got %s", got)
+	}
+	if got := client.ttlFor(BaseURL + "/type/electric/"); got != time.Minute {
+		t.Errorf("expected overridden type TTL of 1m, got %s", got)
+	}
+	if got := client.ttlFor(BaseURL + "/pokemon-species/pikachu/"); got != StaticDataTTL {
+		t.Errorf("expected species TTL to be unaffected, got %s", got)
+	}
+
+	// Overrides on one client must not leak into the defaults of another.
+	if got := NewClient(WithoutSnapshot()).ttlFor(BaseURL + "/type/electric/"); got != StaticDataTTL {
+		t.Errorf("expected default type TTL on a new client, got %s", got)
+	}
+}
@@ -1,6 +1,17 @@
 // Package pokeapi provides types and utilities for interacting with the PokeAPI.
 package pokeapi
 
+// fullSchema is implemented by response types that declare every field of
+// their endpoint, so that strict decoding can be applied to them.
+type fullSchema interface {
+	fullSchema()
+}
+
+func (*LocationAreasResponse) fullSchema() {}
+func (*PokemonListResponse) fullSchema()   {}
+func (*LocationAreaResponse) fullSchema()  {}
+func (*Pokemon) fullSchema()               {}
+
 // LocationAreasResponse represents the paginated response from the location-area list endpoint.
 type LocationAreasResponse struct {
 	Count    int             `json:"count"`
@@ -9,6 +20,14 @@ type LocationAreasResponse struct {
 	Results  []NamedResource `json:"results"`
 }
 
+// PokemonListResponse represents the paginated response from the pokemon list endpoint.
+type PokemonListResponse struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []NamedResource `json:"results"`
+}
+
 // LocationAreaResponse represents the response from a specific location-area endpoint.
 // It contains detailed information about Pokemon encounters in that area.
 type LocationAreaResponse struct {
@@ -59,12 +78,14 @@ type VersionEncounterGroup struct {
 }
 
 // EncounterDetail describes the specifics of how a Pokemon encounter occurs.
+// ConditionValues lists the conditions (time of day, season, swarms, ...)
+// that must hold for the encounter; it is empty when there are none.
 type EncounterDetail struct {
-	MinLevel        int           `json:"min_level"`
-	MaxLevel        int           `json:"max_level"`
-	Chance          int           `json:"chance"`
-	Method          NamedResource `json:"method"`
-	ConditionValues []any         `json:"condition_values"`
+	MinLevel        int             `json:"min_level"`
+	MaxLevel        int             `json:"max_level"`
+	Chance          int             `json:"chance"`
+	Method          NamedResource   `json:"method"`
+	ConditionValues []NamedResource `json:"condition_values"`
 }
 
 // Pokemon represents detailed information about a specific Pokemon from the PokeAPI.
@@ -91,6 +112,71 @@ type Pokemon struct {
 	Cries                  PokemonCries     `json:"cries"`
 }
 
+// Move represents a move from the PokeAPI move endpoint. Only the fields the
+// REPL uses are declared.
+type Move struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	Accuracy    *int          `json:"accuracy"`
+	Power       *int          `json:"power"`
+	PP          *int          `json:"pp"`
+	Priority    int           `json:"priority"`
+	DamageClass NamedResource `json:"damage_class"`
+	Type        NamedResource `json:"type"`
+}
+
+// PokemonSpecies represents species-level data shared by all forms of a
+// Pokemon. Only the fields the REPL uses are declared.
+type PokemonSpecies struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	CaptureRate        int            `json:"capture_rate"`
+	BaseHappiness      int            `json:"base_happiness"`
+	IsLegendary        bool           `json:"is_legendary"`
+	IsMythical         bool           `json:"is_mythical"`
+	GrowthRate         NamedResource  `json:"growth_rate"`
+	EvolvesFromSpecies *NamedResource `json:"evolves_from_species"`
+	EvolutionChain     APIResource    `json:"evolution_chain"`
+}
+
+// APIResource is a reference to an API resource that has no name, such as an
+// evolution chain.
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+// EvolutionChain represents the evolution family a species belongs to. Only
+// the fields the REPL uses are declared.
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// ChainLink is one stage of an evolution chain. EvolvesTo lists every species
+// this stage can evolve into; it has several entries for branching lines.
+type ChainLink struct {
+	IsBaby    bool          `json:"is_baby"`
+	Species   NamedResource `json:"species"`
+	EvolvesTo []ChainLink   `json:"evolves_to"`
+}
+
+// Stages returns the species names in the chain ordered from the base form to
+// the final evolutions. Each stage lists all of its branches before the next
+// stage begins.
+func (c EvolutionChain) Stages() []string {
+	var names []string
+	stage := []ChainLink{c.Chain}
+	for len(stage) > 0 {
+		var next []ChainLink
+		for _, link := range stage {
+			names = append(names, link.Species.Name)
+			next = append(next, link.EvolvesTo...)
+		}
+		stage = next
+	}
+	return names
+}
+
 // PokemonAbility represents an ability a Pokemon can have.
 type PokemonAbility struct {
 	IsHidden bool          `json:"is_hidden"`
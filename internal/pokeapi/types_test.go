@@ -0,0 +1,26 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEncounterDetailConditionValues(t *testing.T) {
+	data := []byte(`[
+		{"min_level": 10, "max_level": 12, "chance": 20, "method": {"name": "walk"},
+		 "condition_values": [{"name": "time-night", "url": "https://pokeapi.co/api/v2/encounter-condition-value/5/"}]},
+		{"min_level": 20, "max_level": 30, "chance": 60, "method": {"name": "surf"}, "condition_values": []}
+	]`)
+
+	var details []EncounterDetail
+	if err := json.Unmarshal(data, &details); err != nil {
+		t.Fatalf("failed to parse encounter details: %v", err)
+	}
+
+	if len(details[0].ConditionValues) != 1 || details[0].ConditionValues[0].Name != "time-night" {
+		t.Errorf("expected a time-night condition, got %+v", details[0].ConditionValues)
+	}
+	if len(details[1].ConditionValues) != 0 {
+		t.Errorf("expected no conditions, got %+v", details[1].ConditionValues)
+	}
+}